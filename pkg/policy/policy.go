@@ -0,0 +1,164 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package policy provides minimal parsing of IAM policy documents, used to
+// validate and canonicalize the repository and lifecycle policy JSON
+// attached to ECR resources.
+package policy
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// tagConditionPrefixes are the Condition keys IAM recognizes for tag-based
+// access control on ECR resources. The suffix following the slash is the
+// tag key being matched.
+var tagConditionPrefixes = []string{
+	"aws:ResourceTag/",
+	"aws:RequestTag/",
+}
+
+// tagConditionOperators are the Condition operators this package
+// understands as matching tag values. Other operators (e.g. Null,
+// StringNotEquals) aren't meaningful for cross-checking against declared
+// tags and are ignored.
+var tagConditionOperators = map[string]bool{
+	"StringEquals": true,
+	"StringLike":   true,
+}
+
+// document is a minimal, lenient representation of an IAM policy document,
+// sufficient to walk Condition blocks without needing to understand every
+// shape Principal/Action/Resource can take.
+type document struct {
+	Statement []statement `json:"Statement"`
+}
+
+type statement struct {
+	Condition map[string]map[string]stringOrSlice `json:"Condition"`
+}
+
+// stringOrSlice unmarshals an IAM Condition value, which may be a single
+// string or an array of strings.
+type stringOrSlice []string
+
+func (s *stringOrSlice) UnmarshalJSON(data []byte) error {
+	var multi []string
+	if err := json.Unmarshal(data, &multi); err == nil {
+		*s = multi
+		return nil
+	}
+	var single string
+	if err := json.Unmarshal(data, &single); err != nil {
+		return err
+	}
+	*s = []string{single}
+	return nil
+}
+
+// arrayNormalizedStatementKeys are the Statement fields ECR normalizes to a
+// JSON array server-side even when the caller (or a previous GetXxxPolicy
+// response) wrote them as a single string.
+var arrayNormalizedStatementKeys = []string{
+	"Principal", "NotPrincipal", "Action", "NotAction", "Resource", "NotResource",
+}
+
+// Canonicalize re-marshals policyJSON with sorted object keys, no
+// insignificant whitespace, and Principal/Action/Resource (and their Not*
+// counterparts) widened from a bare string to a single-element array, so
+// that two cosmetically different but semantically identical policy
+// documents -- including the normalization ECR itself applies server-side
+// -- compare equal.
+func Canonicalize(policyJSON string) (string, error) {
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(policyJSON), &parsed); err != nil {
+		return "", fmt.Errorf("invalid policy document: %w", err)
+	}
+	normalizeStatementArrays(parsed)
+	canonical, err := json.Marshal(parsed)
+	if err != nil {
+		return "", err
+	}
+	return string(canonical), nil
+}
+
+// normalizeStatementArrays walks parsed looking for a top-level Statement
+// array and widens any arrayNormalizedStatementKeys field found on a
+// statement from a bare string to a single-element []interface{}, in
+// place, matching the normalization ECR applies when it stores a policy.
+func normalizeStatementArrays(parsed interface{}) {
+	doc, ok := parsed.(map[string]interface{})
+	if !ok {
+		return
+	}
+	statements, ok := doc["Statement"].([]interface{})
+	if !ok {
+		return
+	}
+	for _, s := range statements {
+		stmt, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for _, key := range arrayNormalizedStatementKeys {
+			if v, ok := stmt[key].(string); ok {
+				stmt[key] = []interface{}{v}
+			}
+		}
+	}
+}
+
+// ReferencedTagKeys returns the sorted, de-duplicated set of tag keys a
+// policy document references via aws:ResourceTag/<k> or aws:RequestTag/<k>
+// Condition keys under the StringEquals or StringLike operators.
+func ReferencedTagKeys(policyJSON string) ([]string, error) {
+	var doc document
+	if err := json.Unmarshal([]byte(policyJSON), &doc); err != nil {
+		return nil, fmt.Errorf("invalid policy document: %w", err)
+	}
+
+	seen := map[string]bool{}
+	for _, stmt := range doc.Statement {
+		for operator, keys := range stmt.Condition {
+			if !tagConditionOperators[operator] {
+				continue
+			}
+			for conditionKey := range keys {
+				if tagKey, ok := tagKeyFromConditionKey(conditionKey); ok {
+					seen[tagKey] = true
+				}
+			}
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// tagKeyFromConditionKey extracts the tag key from an
+// aws:ResourceTag/<k>-or-aws:RequestTag/<k> Condition key.
+func tagKeyFromConditionKey(conditionKey string) (string, bool) {
+	for _, prefix := range tagConditionPrefixes {
+		if strings.HasPrefix(conditionKey, prefix) {
+			return strings.TrimPrefix(conditionKey, prefix), true
+		}
+	}
+	return "", false
+}
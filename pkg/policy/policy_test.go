@@ -0,0 +1,96 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/aws-controllers-k8s/ecr-controller/pkg/policy"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Canonicalize(t *testing.T) {
+	assert := assert.New(t)
+
+	spaced := `{
+		"Version": "2012-10-17",
+		"Statement": [ { "Effect": "Allow", "Action": "ecr:GetDownloadUrlForLayer" } ]
+	}`
+	reordered := `{"Statement":[{"Action":"ecr:GetDownloadUrlForLayer","Effect":"Allow"}],"Version":"2012-10-17"}`
+
+	ca, err := policy.Canonicalize(spaced)
+	assert.NoError(err)
+	cb, err := policy.Canonicalize(reordered)
+	assert.NoError(err)
+	assert.Equal(ca, cb)
+
+	_, err = policy.Canonicalize("not json")
+	assert.Error(err)
+}
+
+func Test_Canonicalize_ArrayNormalization(t *testing.T) {
+	assert := assert.New(t)
+
+	bareString := `{
+		"Version": "2012-10-17",
+		"Statement": [ { "Effect": "Allow", "Action": "ecr:GetDownloadUrlForLayer", "Resource": "*" } ]
+	}`
+	singleElementArray := `{
+		"Version": "2012-10-17",
+		"Statement": [ { "Effect": "Allow", "Action": ["ecr:GetDownloadUrlForLayer"], "Resource": ["*"] } ]
+	}`
+
+	ca, err := policy.Canonicalize(bareString)
+	assert.NoError(err)
+	cb, err := policy.Canonicalize(singleElementArray)
+	assert.NoError(err)
+	assert.Equal(ca, cb)
+}
+
+func Test_ReferencedTagKeys(t *testing.T) {
+	assert := assert.New(t)
+
+	doc := `{
+		"Version": "2012-10-17",
+		"Statement": [
+			{
+				"Effect": "Allow",
+				"Action": "ecr:GetDownloadUrlForLayer",
+				"Condition": {
+					"StringEquals": {
+						"aws:ResourceTag/Environment": "prod"
+					},
+					"StringLike": {
+						"aws:RequestTag/Team": ["platform", "security"]
+					},
+					"StringNotEquals": {
+						"aws:ResourceTag/Ignored": "x"
+					}
+				}
+			}
+		]
+	}`
+
+	keys, err := policy.ReferencedTagKeys(doc)
+	assert.NoError(err)
+	assert.Equal([]string{"Environment", "Team"}, keys)
+
+	noConditions := `{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":"ecr:GetDownloadUrlForLayer"}]}`
+	keys, err = policy.ReferencedTagKeys(noConditions)
+	assert.NoError(err)
+	assert.Empty(keys)
+
+	_, err = policy.ReferencedTagKeys("not json")
+	assert.Error(err)
+}
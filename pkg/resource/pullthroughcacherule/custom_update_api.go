@@ -0,0 +1,42 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package pullthroughcacherule
+
+import (
+	"context"
+
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+)
+
+// customUpdatePullThroughCacheRule implements specialized logic for
+// handling PullThroughCacheRule updates. There is no
+// UpdatePullThroughCacheRule API call -- every field (EcrRepositoryPrefix,
+// UpstreamRegistryURL, UpstreamRegistry, CredentialArn) is immutable once a
+// rule exists, so any diff is applied by deleting the existing rule and
+// recreating it with the desired configuration.
+func (rm *resourceManager) customUpdatePullThroughCacheRule(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (*resource, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customUpdatePullThroughCacheRule")
+	var err error
+	defer exit(err)
+
+	if err = rm.sdkDelete(ctx, latest); err != nil {
+		return nil, err
+	}
+	return rm.sdkCreate(ctx, desired)
+}
@@ -0,0 +1,216 @@
+package registry
+
+import (
+	"context"
+	"errors"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/ecr"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/ecr-controller/apis/v1alpha1"
+)
+
+// ConditionTypePolicySynced is set to True once Spec.Policy has been
+// successfully applied.
+const ConditionTypePolicySynced ackv1alpha1.ConditionType = "ACK.PolicySynced"
+
+// describeRegistry populates the effective replication configuration and
+// registry ID via DescribeRegistry.
+func (rm *resourceManager) describeRegistry(
+	ctx context.Context,
+	ko *svcapitypes.Registry,
+) error {
+	resp, err := rm.sdkapi.DescribeRegistry(ctx, &svcsdk.DescribeRegistryInput{})
+	rm.metrics.RecordAPICall("GET", "DescribeRegistry", err)
+	if err != nil {
+		return err
+	}
+	ko.Status.RegistryID = resp.RegistryId
+	ko.Status.ReplicationConfiguration = replicationConfigurationFromSDK(resp.ReplicationConfiguration)
+	return nil
+}
+
+// describeRegistryScanningConfiguration populates the effective scanning
+// configuration via GetRegistryScanningConfiguration.
+func (rm *resourceManager) describeRegistryScanningConfiguration(
+	ctx context.Context,
+	ko *svcapitypes.Registry,
+) error {
+	resp, err := rm.sdkapi.GetRegistryScanningConfiguration(ctx, &svcsdk.GetRegistryScanningConfigurationInput{})
+	rm.metrics.RecordAPICall("GET", "GetRegistryScanningConfiguration", err)
+	if err != nil {
+		return err
+	}
+	ko.Status.ScanningConfiguration = scanningConfigurationFromSDK(resp.ScanningConfiguration)
+	return nil
+}
+
+// describeRegistryPolicy populates the effective registry policy via
+// GetRegistryPolicy. A missing policy is not an error.
+func (rm *resourceManager) describeRegistryPolicy(
+	ctx context.Context,
+	ko *svcapitypes.Registry,
+) error {
+	resp, err := rm.sdkapi.GetRegistryPolicy(ctx, &svcsdk.GetRegistryPolicyInput{})
+	rm.metrics.RecordAPICall("GET", "GetRegistryPolicy", err)
+	if err != nil {
+		if rm.isPolicyNotFound(err) {
+			ko.Status.Policy = nil
+			return nil
+		}
+		return err
+	}
+	ko.Status.Policy = resp.PolicyText
+	return nil
+}
+
+// isPolicyNotFound returns true if err indicates that no registry policy
+// has been set.
+func (rm *resourceManager) isPolicyNotFound(err error) bool {
+	var nf *svcsdktypes.RegistryPolicyNotFoundException
+	return errors.As(err, &nf)
+}
+
+// customUpdateRegistry reconciles Spec.Policy against PutRegistryPolicy/
+// DeleteRegistryPolicy.
+//
+// ReplicationConfiguration and ScanningConfiguration are deliberately not
+// synced here: they're owned by their own dedicated CRDs (
+// RegistryReplicationConfiguration, RegistryScanningConfiguration), so that only
+// one resourceManager ever issues a Put call for either, for a given
+// registry. Registry still reads both back into Status below, for
+// visibility.
+func (rm *resourceManager) customUpdateRegistry(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (*resource, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.customUpdateRegistry")
+	var err error
+	defer exit(err)
+
+	ko := desired.ko.DeepCopy()
+	rm.setStatusDefaults(ko)
+
+	policyErr := rm.syncPolicy(ctx, ko)
+	rm.setSectionCondition(ko, ConditionTypePolicySynced, policyErr)
+
+	if policyErr != nil {
+		err = policyErr
+		return &resource{ko}, err
+	}
+
+	if err = rm.describeRegistry(ctx, ko); err != nil {
+		return &resource{ko}, err
+	}
+	if err = rm.describeRegistryScanningConfiguration(ctx, ko); err != nil {
+		return &resource{ko}, err
+	}
+	if err = rm.describeRegistryPolicy(ctx, ko); err != nil {
+		return &resource{ko}, err
+	}
+
+	return &resource{ko}, nil
+}
+
+// setSectionCondition records the outcome of reconciling a single
+// sub-configuration as its own condition on the resource's status.
+func (rm *resourceManager) setSectionCondition(
+	ko *svcapitypes.Registry,
+	condType ackv1alpha1.ConditionType,
+	sectionErr error,
+) {
+	var cond *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == condType {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: condType}
+		ko.Status.Conditions = append(ko.Status.Conditions, cond)
+	}
+	if sectionErr != nil {
+		cond.Status = corev1.ConditionFalse
+		msg := sectionErr.Error()
+		cond.Message = &msg
+	} else {
+		cond.Status = corev1.ConditionTrue
+		cond.Message = nil
+	}
+	now := metav1.Now()
+	cond.LastTransitionTime = &now
+}
+
+// syncPolicy applies Spec.Policy via PutRegistryPolicy, or removes it via
+// DeleteRegistryPolicy when unset.
+func (rm *resourceManager) syncPolicy(
+	ctx context.Context,
+	ko *svcapitypes.Registry,
+) error {
+	if ko.Spec.Policy == nil || *ko.Spec.Policy == "" {
+		_, err := rm.sdkapi.DeleteRegistryPolicy(ctx, &svcsdk.DeleteRegistryPolicyInput{})
+		rm.metrics.RecordAPICall("DELETE", "DeleteRegistryPolicy", err)
+		if err != nil && rm.isPolicyNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	_, err := rm.sdkapi.PutRegistryPolicy(ctx, &svcsdk.PutRegistryPolicyInput{
+		PolicyText: ko.Spec.Policy,
+	})
+	rm.metrics.RecordAPICall("UPDATE", "PutRegistryPolicy", err)
+	return err
+}
+
+func replicationConfigurationFromSDK(rc *svcsdktypes.ReplicationConfiguration) *svcapitypes.ReplicationConfiguration {
+	if rc == nil {
+		return nil
+	}
+	out := &svcapitypes.ReplicationConfiguration{}
+	for _, rule := range rc.Rules {
+		outRule := &svcapitypes.ReplicationRule{}
+		for _, dest := range rule.Destinations {
+			outRule.Destinations = append(outRule.Destinations, &svcapitypes.ReplicationDestination{
+				Region:     dest.Region,
+				RegistryID: dest.RegistryId,
+			})
+		}
+		for _, filter := range rule.RepositoryFilters {
+			filterType := string(filter.FilterType)
+			outRule.RepositoryFilters = append(outRule.RepositoryFilters, &svcapitypes.ReplicationRepositoryFilter{
+				Filter:     filter.Filter,
+				FilterType: &filterType,
+			})
+		}
+		out.Rules = append(out.Rules, outRule)
+	}
+	return out
+}
+
+func scanningConfigurationFromSDK(sc *svcsdktypes.RegistryScanningConfiguration) *svcapitypes.ScanningConfiguration {
+	if sc == nil {
+		return nil
+	}
+	scanType := string(sc.ScanType)
+	out := &svcapitypes.ScanningConfiguration{ScanType: &scanType}
+	for _, rule := range sc.Rules {
+		freq := string(rule.ScanFrequency)
+		outRule := &svcapitypes.RegistryScanningRule{ScanFrequency: &freq}
+		for _, filter := range rule.RepositoryFilters {
+			filterType := string(filter.FilterType)
+			outRule.RepositoryFilters = append(outRule.RepositoryFilters, &svcapitypes.ScanningRepositoryFilter{
+				Filter:     filter.Filter,
+				FilterType: &filterType,
+			})
+		}
+		out.Rules = append(out.Rules, outRule)
+	}
+	return out
+}
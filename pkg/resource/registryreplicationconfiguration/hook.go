@@ -0,0 +1,151 @@
+package registryreplicationconfiguration
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/ecr"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/ecr-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/ecr-controller/pkg/ruleset"
+)
+
+// ConditionTypeReplicationConfigurationSynced is set to True once the
+// desired rule set has been successfully applied via
+// PutReplicationConfiguration.
+const ConditionTypeReplicationConfigurationSynced ackv1alpha1.ConditionType = "ACK.ReplicationConfigurationSynced"
+
+// describeRegistryReplicationConfiguration populates the effective
+// replication configuration's registry ID via DescribeRegistry.
+// PutReplicationConfiguration does not return the registry ID directly, so
+// this piggybacks on DescribeRegistry's default-registry behavior being
+// implicit in every unqualified ECR API call.
+func (rm *resourceManager) describeRegistryReplicationConfiguration(
+	ctx context.Context,
+	ko *svcapitypes.RegistryReplicationConfiguration,
+) error {
+	resp, err := rm.sdkapi.DescribeRegistry(ctx, &svcsdk.DescribeRegistryInput{})
+	rm.metrics.RecordAPICall("GET", "DescribeRegistry", err)
+	if err != nil {
+		return err
+	}
+	ko.Status.RegistryID = resp.RegistryId
+	return nil
+}
+
+// syncReplicationConfiguration reconciles the rule set as a whole: it
+// computes which rules (keyed by their destination + repository filter
+// set) are being added or removed relative to latest, records that as the
+// ConditionTypeReplicationConfigurationSynced condition message, then
+// replaces the entire rule set in a single PutReplicationConfiguration call
+// -- the API has no per-rule add/remove operation. The Put is skipped
+// entirely when the rule set doesn't actually differ from latest, so a
+// no-op reconcile doesn't re-issue the same write every time.
+func (rm *resourceManager) syncReplicationConfiguration(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (*resource, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncReplicationConfiguration")
+	var err error
+	defer exit(err)
+
+	ko := desired.ko.DeepCopy()
+	rm.setStatusDefaults(ko)
+
+	added, removed := ruleset.Delta(ruleKeys(latest.ko.Spec.Rules), ruleKeys(desired.ko.Spec.Rules))
+
+	if len(added) > 0 || len(removed) > 0 {
+		input := &svcsdk.PutReplicationConfigurationInput{
+			ReplicationConfiguration: &svcsdktypes.ReplicationConfiguration{
+				Rules: replicationRulesToSDK(desired.ko.Spec.Rules),
+			},
+		}
+		_, err = rm.sdkapi.PutReplicationConfiguration(ctx, input)
+		rm.metrics.RecordAPICall("UPDATE", "PutReplicationConfiguration", err)
+	}
+	ruleset.SetSyncedCondition(&ko.Status.Conditions, ConditionTypeReplicationConfigurationSynced, err, added, removed)
+	if err != nil {
+		return &resource{ko}, err
+	}
+
+	if err = rm.describeRegistryReplicationConfiguration(ctx, ko); err != nil {
+		return &resource{ko}, err
+	}
+
+	return &resource{ko}, nil
+}
+
+// ruleKey identifies a ReplicationRule by its destination set and
+// repository filter set, so two rule sets can be diffed by rule identity
+// rather than by slice position.
+func ruleKey(rule *svcapitypes.ReplicationRule) string {
+	dests := make([]string, 0, len(rule.Destinations))
+	for _, d := range rule.Destinations {
+		if d == nil {
+			continue
+		}
+		dests = append(dests, aws.ToString(d.RegistryID)+":"+aws.ToString(d.Region))
+	}
+	sort.Strings(dests)
+
+	filters := make([]string, 0, len(rule.RepositoryFilters))
+	for _, f := range rule.RepositoryFilters {
+		if f == nil {
+			continue
+		}
+		filters = append(filters, aws.ToString(f.FilterType)+":"+aws.ToString(f.Filter))
+	}
+	sort.Strings(filters)
+
+	return strings.Join(dests, ",") + "|" + strings.Join(filters, ",")
+}
+
+// ruleKeys maps rules to their ruleKey, skipping nils, for use with
+// ruleset.Delta.
+func ruleKeys(rules []*svcapitypes.ReplicationRule) []string {
+	keys := make([]string, 0, len(rules))
+	for _, r := range rules {
+		if r != nil {
+			keys = append(keys, ruleKey(r))
+		}
+	}
+	return keys
+}
+
+// replicationRulesToSDK converts Spec.Rules into the SDK's rule type.
+func replicationRulesToSDK(rules []*svcapitypes.ReplicationRule) []svcsdktypes.ReplicationRule {
+	var out []svcsdktypes.ReplicationRule
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		sdkRule := svcsdktypes.ReplicationRule{}
+		for _, dest := range rule.Destinations {
+			if dest == nil {
+				continue
+			}
+			sdkRule.Destinations = append(sdkRule.Destinations, svcsdktypes.ReplicationDestination{
+				Region:     aws.String(aws.ToString(dest.Region)),
+				RegistryId: aws.String(aws.ToString(dest.RegistryID)),
+			})
+		}
+		for _, filter := range rule.RepositoryFilters {
+			if filter == nil {
+				continue
+			}
+			sdkRule.RepositoryFilters = append(sdkRule.RepositoryFilters, svcsdktypes.RepositoryFilter{
+				Filter:     aws.String(aws.ToString(filter.Filter)),
+				FilterType: svcsdktypes.RepositoryFilterType(aws.ToString(filter.FilterType)),
+			})
+		}
+		out = append(out, sdkRule)
+	}
+	return out
+}
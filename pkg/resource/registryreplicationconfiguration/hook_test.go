@@ -0,0 +1,57 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package registryreplicationconfiguration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/ecr-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/ecr-controller/pkg/ruleset"
+)
+
+func prefixMatchRule(region, accountID, filter string) *svcapitypes.ReplicationRule {
+	filterType := "PREFIX_MATCH"
+	return &svcapitypes.ReplicationRule{
+		Destinations: []*svcapitypes.ReplicationDestination{
+			{Region: &region, RegistryID: &accountID},
+		},
+		RepositoryFilters: []*svcapitypes.ReplicationRepositoryFilter{
+			{Filter: &filter, FilterType: &filterType},
+		},
+	}
+}
+
+func Test_ruleKeys_Delta(t *testing.T) {
+	assert := assert.New(t)
+
+	current := []*svcapitypes.ReplicationRule{prefixMatchRule("us-west-2", "123456789012", "prod/*")}
+	desired := []*svcapitypes.ReplicationRule{
+		prefixMatchRule("us-west-2", "123456789012", "prod/*"),
+		prefixMatchRule("eu-west-1", "123456789012", "staging/*"),
+	}
+
+	added, removed := ruleset.Delta(ruleKeys(current), ruleKeys(desired))
+	assert.Len(added, 1)
+	assert.Empty(removed)
+
+	added, removed = ruleset.Delta(ruleKeys(desired), ruleKeys(current))
+	assert.Empty(added)
+	assert.Len(removed, 1)
+
+	added, removed = ruleset.Delta(ruleKeys(current), ruleKeys(current))
+	assert.Empty(added)
+	assert.Empty(removed)
+}
@@ -0,0 +1,136 @@
+package registryscanningconfiguration
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/ecr"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/ecr-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/ecr-controller/pkg/ruleset"
+)
+
+// ConditionTypeScanningConfigurationSynced is set to True once the desired
+// rule set has been successfully applied via
+// PutRegistryScanningConfiguration.
+const ConditionTypeScanningConfigurationSynced ackv1alpha1.ConditionType = "ACK.ScanningConfigurationSynced"
+
+// describeRegistryScanningConfiguration populates the effective scanning
+// configuration's registry ID from the RegistryId that
+// GetRegistryScanningConfiguration returns for the calling account's
+// default registry.
+func (rm *resourceManager) describeRegistryScanningConfiguration(
+	ctx context.Context,
+	ko *svcapitypes.RegistryScanningConfiguration,
+) error {
+	resp, err := rm.sdkapi.GetRegistryScanningConfiguration(ctx, &svcsdk.GetRegistryScanningConfigurationInput{})
+	rm.metrics.RecordAPICall("GET", "GetRegistryScanningConfiguration", err)
+	if err != nil {
+		return err
+	}
+	if resp.RegistryId != nil {
+		ko.Status.RegistryID = resp.RegistryId
+	}
+	return nil
+}
+
+// syncScanningConfiguration reconciles the rule set as a whole: it computes
+// which rules (keyed by their scan frequency + repository filter set) are
+// being added or removed relative to latest, records that as the
+// ConditionTypeScanningConfigurationSynced condition message, then replaces
+// the entire rule set in a single PutRegistryScanningConfiguration call --
+// the API has no per-rule add/remove operation. The Put is skipped
+// entirely when neither the rule set nor ScanType actually differ from
+// latest, so a no-op reconcile doesn't re-issue the same write every time.
+func (rm *resourceManager) syncScanningConfiguration(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) (*resource, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.syncScanningConfiguration")
+	var err error
+	defer exit(err)
+
+	ko := desired.ko.DeepCopy()
+	rm.setStatusDefaults(ko)
+
+	added, removed := ruleset.Delta(ruleKeys(latest.ko.Spec.Rules), ruleKeys(desired.ko.Spec.Rules))
+	scanTypeChanged := aws.ToString(latest.ko.Spec.ScanType) != aws.ToString(desired.ko.Spec.ScanType)
+
+	if len(added) > 0 || len(removed) > 0 || scanTypeChanged {
+		input := &svcsdk.PutRegistryScanningConfigurationInput{
+			ScanType: svcsdktypes.ScanType(aws.ToString(desired.ko.Spec.ScanType)),
+			Rules:    scanningRulesToSDK(desired.ko.Spec.Rules),
+		}
+		_, err = rm.sdkapi.PutRegistryScanningConfiguration(ctx, input)
+		rm.metrics.RecordAPICall("UPDATE", "PutRegistryScanningConfiguration", err)
+	}
+	ruleset.SetSyncedCondition(&ko.Status.Conditions, ConditionTypeScanningConfigurationSynced, err, added, removed)
+	if err != nil {
+		return &resource{ko}, err
+	}
+
+	if err = rm.describeRegistryScanningConfiguration(ctx, ko); err != nil {
+		return &resource{ko}, err
+	}
+
+	return &resource{ko}, nil
+}
+
+// ruleKey identifies a RegistryScanningRule by its scan frequency and
+// repository filter set, so two rule sets can be diffed by rule identity
+// rather than by slice position.
+func ruleKey(rule *svcapitypes.RegistryScanningRule) string {
+	freq := aws.ToString(rule.ScanFrequency)
+	filters := make([]string, 0, len(rule.RepositoryFilters))
+	for _, f := range rule.RepositoryFilters {
+		if f == nil {
+			continue
+		}
+		filters = append(filters, aws.ToString(f.FilterType)+":"+aws.ToString(f.Filter))
+	}
+	sort.Strings(filters)
+	return freq + "|" + strings.Join(filters, ",")
+}
+
+// ruleKeys maps rules to their ruleKey, skipping nils, for use with
+// ruleset.Delta.
+func ruleKeys(rules []*svcapitypes.RegistryScanningRule) []string {
+	keys := make([]string, 0, len(rules))
+	for _, r := range rules {
+		if r != nil {
+			keys = append(keys, ruleKey(r))
+		}
+	}
+	return keys
+}
+
+// scanningRulesToSDK converts Spec.Rules into the SDK's rule type.
+func scanningRulesToSDK(rules []*svcapitypes.RegistryScanningRule) []svcsdktypes.RegistryScanningRule {
+	var out []svcsdktypes.RegistryScanningRule
+	for _, rule := range rules {
+		if rule == nil {
+			continue
+		}
+		sdkRule := svcsdktypes.RegistryScanningRule{
+			ScanFrequency: svcsdktypes.ScanFrequency(aws.ToString(rule.ScanFrequency)),
+		}
+		for _, filter := range rule.RepositoryFilters {
+			if filter == nil {
+				continue
+			}
+			sdkRule.RepositoryFilters = append(sdkRule.RepositoryFilters, svcsdktypes.ScanningRepositoryFilter{
+				Filter:     aws.String(aws.ToString(filter.Filter)),
+				FilterType: svcsdktypes.ScanningRepositoryFilterType(aws.ToString(filter.FilterType)),
+			})
+		}
+		out = append(out, sdkRule)
+	}
+	return out
+}
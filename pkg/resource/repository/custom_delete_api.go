@@ -0,0 +1,155 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	svcsdk "github.com/aws/aws-sdk-go/service/ecr"
+)
+
+// batchDeleteImageLimit is the maximum number of image IDs the
+// BatchDeleteImage API accepts in a single call.
+const batchDeleteImageLimit = 100
+
+// customDeleteRepository implements specialized logic for handling
+// Repository deletion. When the AnnotationDeleteForce annotation is set,
+// every image in the repository is deleted via paginated ListImages +
+// batched BatchDeleteImage calls before DeleteRepository is attempted, so
+// that DeleteRepository doesn't fail with RepositoryNotEmptyException.
+// Otherwise, that exception is translated into a clearer error so users
+// know to set the annotation.
+func (rm *resourceManager) customDeleteRepository(
+	ctx context.Context,
+	r *resource,
+) error {
+	if GetDeleteForce(&r.ko.ObjectMeta) {
+		if err := rm.deleteAllImages(ctx, r); err != nil {
+			return err
+		}
+	}
+
+	input, err := rm.newDeleteRequestPayload(r)
+	if err != nil {
+		return err
+	}
+	_, respErr := rm.sdkapi.DeleteRepositoryWithContext(ctx, input)
+	rm.metrics.RecordAPICall("DELETE", "DeleteRepository", respErr)
+	if respErr != nil {
+		if awsErr, ok := ackerr.AWSError(respErr); ok && awsErr.Code() == svcsdk.ErrCodeRepositoryNotEmptyException {
+			return fmt.Errorf(
+				"repository still contains images; set the %s annotation to true to delete them automatically: %w",
+				AnnotationDeleteForce, respErr,
+			)
+		}
+		return respErr
+	}
+	return nil
+}
+
+// deleteAllImages paginates ListImages and issues BatchDeleteImage calls,
+// chunked to batchDeleteImageLimit, to empty out a repository before it is
+// deleted.
+func (rm *resourceManager) deleteAllImages(
+	ctx context.Context,
+	r *resource,
+) error {
+	var nextToken *string
+	for {
+		listInput := &svcsdk.ListImagesInput{
+			RepositoryName: r.ko.Spec.Name,
+			RegistryId:     r.ko.Spec.RegistryID,
+			NextToken:      nextToken,
+		}
+		listResp, err := rm.sdkapi.ListImagesWithContext(ctx, listInput)
+		rm.metrics.RecordAPICall("READ_MANY", "ListImages", err)
+		if err != nil {
+			return err
+		}
+
+		if err := rm.batchDeleteImages(ctx, r, listResp.ImageIds); err != nil {
+			return err
+		}
+
+		if listResp.NextToken == nil {
+			return nil
+		}
+		nextToken = listResp.NextToken
+	}
+}
+
+// batchDeleteImages deletes imageIDs in chunks of at most
+// batchDeleteImageLimit, returning an error if any image fails to delete.
+func (rm *resourceManager) batchDeleteImages(
+	ctx context.Context,
+	r *resource,
+	imageIDs []*svcsdk.ImageIdentifier,
+) error {
+	for _, chunk := range chunkImageIDs(imageIDs, batchDeleteImageLimit) {
+		resp, err := rm.sdkapi.BatchDeleteImageWithContext(ctx, &svcsdk.BatchDeleteImageInput{
+			RepositoryName: r.ko.Spec.Name,
+			RegistryId:     r.ko.Spec.RegistryID,
+			ImageIds:       chunk,
+		})
+		rm.metrics.RecordAPICall("DELETE", "BatchDeleteImage", err)
+		if err != nil {
+			return err
+		}
+		if len(resp.Failures) > 0 {
+			return fmt.Errorf(
+				"failed to delete %d of %d images from repository %s: %s",
+				len(resp.Failures), len(chunk), *r.ko.Spec.Name, batchDeleteImageFailuresString(resp.Failures),
+			)
+		}
+	}
+	return nil
+}
+
+// chunkImageIDs splits ids into slices of at most size elements each,
+// preserving order. It is the pagination boundary BatchDeleteImage's
+// 100-image-per-call limit requires.
+func chunkImageIDs(ids []*svcsdk.ImageIdentifier, size int) [][]*svcsdk.ImageIdentifier {
+	var chunks [][]*svcsdk.ImageIdentifier
+	for start := 0; start < len(ids); start += size {
+		end := start + size
+		if end > len(ids) {
+			end = len(ids)
+		}
+		chunks = append(chunks, ids[start:end])
+	}
+	return chunks
+}
+
+// batchDeleteImageFailuresString renders BatchDeleteImage failures into a
+// short human-readable summary for error messages.
+func batchDeleteImageFailuresString(failures []*svcsdk.ImageFailure) string {
+	msg := ""
+	for i, f := range failures {
+		if i > 0 {
+			msg += "; "
+		}
+		digest := ""
+		if f.ImageId != nil && f.ImageId.ImageDigest != nil {
+			digest = *f.ImageId.ImageDigest
+		}
+		reason := ""
+		if f.FailureReason != nil {
+			reason = *f.FailureReason
+		}
+		msg += fmt.Sprintf("%s: %s", digest, reason)
+	}
+	return msg
+}
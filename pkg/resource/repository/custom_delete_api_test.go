@@ -0,0 +1,171 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	svcsdk "github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/aws/aws-sdk-go/service/ecr/ecriface"
+	"github.com/stretchr/testify/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/ecr-controller/apis/v1alpha1"
+)
+
+// mockECRAPI stubs only the calls the resourceManager methods under test
+// make, embedding ecriface.ECRAPI so it satisfies the rest of the (very
+// large) interface without implementing it.
+type mockECRAPI struct {
+	ecriface.ECRAPI
+
+	listImagesPages []*svcsdk.ListImagesOutput
+	listImagesCalls int
+
+	describeImagesPages []*svcsdk.DescribeImagesOutput
+	describeImagesCalls int
+	// signatureTags is consulted by DescribeImagesWithContext when called
+	// with a single ImageTag filter, as hasImageTag does: the tag exists
+	// if present and true, and is reported ImageNotFoundException
+	// otherwise.
+	signatureTags map[string]bool
+
+	batchDeleteResponses []*svcsdk.BatchDeleteImageOutput
+	batchDeleteCalls     [][]*svcsdk.ImageIdentifier
+}
+
+func (m *mockECRAPI) ListImagesWithContext(_ context.Context, _ *svcsdk.ListImagesInput, _ ...request.Option) (*svcsdk.ListImagesOutput, error) {
+	out := m.listImagesPages[m.listImagesCalls]
+	m.listImagesCalls++
+	return out, nil
+}
+
+func (m *mockECRAPI) BatchDeleteImageWithContext(_ context.Context, input *svcsdk.BatchDeleteImageInput, _ ...request.Option) (*svcsdk.BatchDeleteImageOutput, error) {
+	m.batchDeleteCalls = append(m.batchDeleteCalls, input.ImageIds)
+	return m.batchDeleteResponses[len(m.batchDeleteCalls)-1], nil
+}
+
+// DescribeImagesWithContext serves describeAllImages' paginated,
+// unfiltered calls from describeImagesPages, and hasImageTag's
+// single-tag lookups from signatureTags.
+func (m *mockECRAPI) DescribeImagesWithContext(_ context.Context, input *svcsdk.DescribeImagesInput, _ ...request.Option) (*svcsdk.DescribeImagesOutput, error) {
+	if len(input.ImageIds) == 1 && input.ImageIds[0].ImageTag != nil {
+		tag := *input.ImageIds[0].ImageTag
+		if m.signatureTags[tag] {
+			return &svcsdk.DescribeImagesOutput{}, nil
+		}
+		return nil, awserr.New(svcsdk.ErrCodeImageNotFoundException, "image not found", nil)
+	}
+	out := m.describeImagesPages[m.describeImagesCalls]
+	m.describeImagesCalls++
+	return out, nil
+}
+
+func testResource() *resource {
+	name := "my-repo"
+	registryID := "123456789012"
+	return &resource{&svcapitypes.Repository{
+		Spec: svcapitypes.RepositorySpec{Name: &name, RegistryID: &registryID},
+	}}
+}
+
+func Test_deleteAllImages_paginatesListImages(t *testing.T) {
+	assert := assert.New(t)
+
+	nextToken := "page-2"
+	mock := &mockECRAPI{
+		listImagesPages: []*svcsdk.ListImagesOutput{
+			{ImageIds: imageIDs(1), NextToken: &nextToken},
+			{ImageIds: imageIDs(1)},
+		},
+		batchDeleteResponses: []*svcsdk.BatchDeleteImageOutput{{}, {}},
+	}
+	rm := &resourceManager{sdkapi: mock, metrics: ackmetrics.NewMetrics("ecr")}
+
+	err := rm.deleteAllImages(context.Background(), testResource())
+	assert.NoError(err)
+	assert.Equal(2, mock.listImagesCalls)
+	assert.Len(mock.batchDeleteCalls, 2)
+}
+
+func Test_batchDeleteImages_returnsErrorOnPartialFailure(t *testing.T) {
+	assert := assert.New(t)
+
+	digest := "sha256:bad"
+	reason := "ImageNotFoundException"
+	mock := &mockECRAPI{
+		batchDeleteResponses: []*svcsdk.BatchDeleteImageOutput{
+			{
+				Failures: []*svcsdk.ImageFailure{
+					{ImageId: &svcsdk.ImageIdentifier{ImageDigest: &digest}, FailureReason: &reason},
+				},
+			},
+		},
+	}
+	rm := &resourceManager{sdkapi: mock, metrics: ackmetrics.NewMetrics("ecr")}
+
+	err := rm.batchDeleteImages(context.Background(), testResource(), imageIDs(1))
+	assert.Error(err)
+	assert.Contains(err.Error(), digest)
+	assert.Contains(err.Error(), reason)
+}
+
+func imageIDs(n int) []*svcsdk.ImageIdentifier {
+	ids := make([]*svcsdk.ImageIdentifier, n)
+	for i := range ids {
+		digest := "sha256:image"
+		ids[i] = &svcsdk.ImageIdentifier{ImageDigest: &digest}
+	}
+	return ids
+}
+
+func Test_chunkImageIDs(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Empty(chunkImageIDs(imageIDs(0), batchDeleteImageLimit))
+
+	chunks := chunkImageIDs(imageIDs(50), batchDeleteImageLimit)
+	assert.Len(chunks, 1)
+	assert.Len(chunks[0], 50)
+
+	chunks = chunkImageIDs(imageIDs(batchDeleteImageLimit), batchDeleteImageLimit)
+	assert.Len(chunks, 1)
+	assert.Len(chunks[0], batchDeleteImageLimit)
+
+	chunks = chunkImageIDs(imageIDs(250), batchDeleteImageLimit)
+	assert.Len(chunks, 3)
+	assert.Len(chunks[0], batchDeleteImageLimit)
+	assert.Len(chunks[1], batchDeleteImageLimit)
+	assert.Len(chunks[2], 50)
+}
+
+func Test_batchDeleteImageFailuresString(t *testing.T) {
+	assert := assert.New(t)
+
+	digest := "sha256:abc"
+	reason := "image referenced by a manifest list"
+	failures := []*svcsdk.ImageFailure{
+		{
+			ImageId:       &svcsdk.ImageIdentifier{ImageDigest: &digest},
+			FailureReason: &reason,
+		},
+	}
+
+	msg := batchDeleteImageFailuresString(failures)
+	assert.Contains(msg, digest)
+	assert.Contains(msg, reason)
+}
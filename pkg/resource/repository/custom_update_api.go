@@ -15,20 +15,70 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	svcsdk "github.com/aws/aws-sdk-go-v2/service/ecr"
-	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/ecr/types"
-
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go/aws"
+	svcsdk "github.com/aws/aws-sdk-go/service/ecr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/ecr-controller/apis/v1alpha1"
+)
+
+const (
+	// AnnotationLifecyclePolicyPreview is an annotation whose value
+	// indicates whether a lifecycle policy change should be dry-run via
+	// StartLifecyclePolicyPreview/GetLifecyclePolicyPreview before it is
+	// applied with PutLifecyclePolicy.
+	AnnotationLifecyclePolicyPreview = AnnotationPrefix + "lifecycle-policy-preview"
+
+	DefaultLifecyclePolicyPreview = false
+	// lifecyclePolicyPreviewRequeueInterval is how soon a reconcile is
+	// requeued while a lifecycle policy preview is still IN_PROGRESS,
+	// rather than blocking the reconcile on repeated GetLifecyclePolicyPreview
+	// polls.
+	lifecyclePolicyPreviewRequeueInterval = 2 * time.Second
+	// imageObservationRequeueInterval is how often a repository with
+	// Spec.ImageObservation set is requeued so that images pushed between
+	// spec changes are picked up in Status.ObservedImages.
+	imageObservationRequeueInterval = 5 * time.Minute
 )
 
+// GetLifecyclePolicyPreviewEnabled returns whether lifecycle policy changes
+// should be previewed before being applied, as determined by the
+// AnnotationLifecyclePolicyPreview annotation on the object, or the default
+// value otherwise.
+func GetLifecyclePolicyPreviewEnabled(
+	m *metav1.ObjectMeta,
+) bool {
+	resAnnotations := m.GetAnnotations()
+	raw, ok := resAnnotations[AnnotationLifecyclePolicyPreview]
+	if !ok {
+		return DefaultLifecyclePolicyPreview
+	}
+
+	enabled, err := strconv.ParseBool(raw)
+	if err != nil {
+		return DefaultLifecyclePolicyPreview
+	}
+
+	return enabled
+}
+
 var (
-	defaultImageScanningConfig = svcsdktypes.ImageScanningConfiguration{
-		ScanOnPush: false,
+	defaultImageScanningConfig = svcsdk.ImageScanningConfiguration{
+		ScanOnPush: aws.Bool(false),
 	}
-	defaultImageTagMutability = svcsdktypes.ImageTagMutabilityMutable
+	defaultImageTagMutability = aws.String(svcsdk.ImageTagMutabilityMutable)
 )
 
 // customUpdateRepository implements specialized logic for handling Repository
@@ -85,6 +135,27 @@ func (rm *resourceManager) customUpdateRepository(
 			return nil, err
 		}
 	}
+
+	if updated.ko.Spec.ImageRetention != nil {
+		updated, err = rm.pruneImages(ctx, updated)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if updated.ko.Spec.ImageObservation != nil {
+		ko := updated.ko.DeepCopy()
+		if err = rm.setObservedImages(ctx, ko); err != nil {
+			return nil, err
+		}
+		updated = &resource{ko}
+		// Images can be pushed to the repository at any time, independently
+		// of spec changes, so requeue periodically to keep
+		// Status.ObservedImages current rather than only refreshing it on
+		// the next spec-driven reconcile.
+		return updated, ackrequeue.NeededAfter(nil, imageObservationRequeueInterval)
+	}
+
 	return updated, nil
 }
 
@@ -108,12 +179,12 @@ func (rm *resourceManager) updateImageScanningConfiguration(
 		// configuration field should always be set...
 		input.ImageScanningConfiguration = &defaultImageScanningConfig
 	} else {
-		isc := &svcsdktypes.ImageScanningConfiguration{
-			ScanOnPush: *dspec.ImageScanningConfiguration.ScanOnPush,
+		isc := &svcsdk.ImageScanningConfiguration{
+			ScanOnPush: dspec.ImageScanningConfiguration.ScanOnPush,
 		}
 		input.ImageScanningConfiguration = isc
 	}
-	_, err = rm.sdkapi.PutImageScanningConfiguration(ctx, input)
+	_, err = rm.sdkapi.PutImageScanningConfigurationWithContext(ctx, input)
 	rm.metrics.RecordAPICall("UPDATE", "PutImageScanningConfiguration", err)
 	if err != nil {
 		return nil, err
@@ -141,9 +212,9 @@ func (rm *resourceManager) updateImageTagMutability(
 		// configuration field should always be set...
 		input.ImageTagMutability = defaultImageTagMutability
 	} else {
-		input.ImageTagMutability = svcsdktypes.ImageTagMutability(*dspec.ImageTagMutability)
+		input.ImageTagMutability = dspec.ImageTagMutability
 	}
-	_, err = rm.sdkapi.PutImageTagMutability(ctx, input)
+	_, err = rm.sdkapi.PutImageTagMutabilityWithContext(ctx, input)
 	rm.metrics.RecordAPICall("UPDATE", "PutImageTagMutability", err)
 	if err != nil {
 		return nil, err
@@ -168,13 +239,32 @@ func (rm *resourceManager) updateLifecyclePolicy(
 		return rm.deleteLifecyclePolicy(ctx, desired)
 	}
 
+	if GetLifecyclePolicyPreviewEnabled(&desired.ko.ObjectMeta) {
+		previewed, err := rm.previewLifecyclePolicy(ctx, desired)
+		if err != nil {
+			// Either a real error, or previewLifecyclePolicy asking to be
+			// requeued because the preview is still IN_PROGRESS or just
+			// EXPIRED and needs restarting -- either way, don't fall through
+			// to PutLifecyclePolicy.
+			return previewed, err
+		}
+		if previewed.ko.Status.LifecyclePolicyPreview == nil ||
+			aws.StringValue(previewed.ko.Status.LifecyclePolicyPreview.Status) != svcsdk.LifecyclePolicyPreviewStatusComplete {
+			// FAILED is the only way to reach here without a requeue -- the
+			// caller surfaces it via the Terminal condition set in
+			// previewLifecyclePolicy.
+			return previewed, nil
+		}
+		desired = previewed
+	}
+
 	input := &svcsdk.PutLifecyclePolicyInput{
 		RepositoryName:      dspec.Name,
 		RegistryId:          dspec.RegistryID,
 		LifecyclePolicyText: dspec.LifecyclePolicy,
 	}
 
-	_, err = rm.sdkapi.PutLifecyclePolicy(ctx, input)
+	_, err = rm.sdkapi.PutLifecyclePolicyWithContext(ctx, input)
 	rm.metrics.RecordAPICall("UPDATE", "PutLifecyclePolicy", err)
 	if err != nil {
 		return nil, err
@@ -182,6 +272,123 @@ func (rm *resourceManager) updateLifecyclePolicy(
 	return desired, nil
 }
 
+// previewLifecyclePolicy dry-runs desired.ko.Spec.LifecyclePolicy via
+// StartLifecyclePolicyPreview and records the outcome of a single
+// GetLifecyclePolicyPreview poll on Status.LifecyclePolicyPreview. While the
+// preview is still IN_PROGRESS, it requeues after
+// lifecyclePolicyPreviewRequeueInterval rather than blocking the reconcile
+// with repeated polls -- the next reconcile calls StartLifecyclePolicyPreview
+// again, which ECR answers with LifecyclePolicyPreviewInProgressException
+// for the same preview, and polling continues from there. A preview that
+// reaches EXPIRED before being polled to COMPLETE is treated the same way:
+// the next reconcile's StartLifecyclePolicyPreview call starts a fresh
+// preview, since ECR no longer considers an expired one in progress. When
+// the preview fails, the common ackv1alpha1.ConditionTypeTerminal condition
+// is set with a message naming the repository; no further failure detail
+// is recorded. The Terminal condition stops the ACK runtime from
+// requeuing so the caller can skip applying the policy.
+func (rm *resourceManager) previewLifecyclePolicy(
+	ctx context.Context,
+	desired *resource,
+) (*resource, error) {
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.previewLifecyclePolicy")
+	var err error
+	defer exit(err)
+
+	dspec := desired.ko.Spec
+	ko := desired.ko.DeepCopy()
+
+	_, err = rm.sdkapi.StartLifecyclePolicyPreviewWithContext(ctx, &svcsdk.StartLifecyclePolicyPreviewInput{
+		RepositoryName:      dspec.Name,
+		RegistryId:          dspec.RegistryID,
+		LifecyclePolicyText: dspec.LifecyclePolicy,
+	})
+	rm.metrics.RecordAPICall("CREATE", "StartLifecyclePolicyPreview", err)
+	if err != nil {
+		// A preview is already in progress for this repository; poll the
+		// existing one rather than failing the reconcile.
+		awsErr, ok := ackerr.AWSError(err)
+		if !ok || awsErr.Code() != svcsdk.ErrCodeLifecyclePolicyPreviewInProgressException {
+			return nil, err
+		}
+	}
+
+	resp, err := rm.sdkapi.GetLifecyclePolicyPreviewWithContext(ctx, &svcsdk.GetLifecyclePolicyPreviewInput{
+		RepositoryName: dspec.Name,
+		RegistryId:     dspec.RegistryID,
+	})
+	rm.metrics.RecordAPICall("GET", "GetLifecyclePolicyPreview", err)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &svcapitypes.LifecyclePolicyPreviewStatus{Status: resp.Status}
+	for action, count := range countExpiringImagesByAction(resp.PreviewResults) {
+		c := count
+		if preview.SummaryCounts == nil {
+			preview.SummaryCounts = map[string]*int64{}
+		}
+		preview.SummaryCounts[action] = &c
+	}
+	for _, result := range resp.PreviewResults {
+		action := ""
+		if result.Action != nil {
+			action = aws.StringValue(result.Action.Type)
+		}
+		preview.Results = append(preview.Results, &svcapitypes.LifecyclePolicyPreviewResult{
+			ImageTags:   result.ImageTags,
+			ImageDigest: result.ImageDigest,
+			Action:      &action,
+		})
+	}
+	ko.Status.LifecyclePolicyPreview = preview
+
+	var cond *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == ackv1alpha1.ConditionTypeTerminal {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: ackv1alpha1.ConditionTypeTerminal}
+		ko.Status.Conditions = append(ko.Status.Conditions, cond)
+	}
+	status := aws.StringValue(resp.Status)
+	if status == svcsdk.LifecyclePolicyPreviewStatusFailed {
+		cond.Status = corev1.ConditionTrue
+		msg := fmt.Sprintf("lifecycle policy preview failed for repository %s", aws.StringValue(dspec.Name))
+		cond.Message = &msg
+		return &resource{ko}, nil
+	}
+	cond.Status = corev1.ConditionFalse
+	cond.Message = nil
+
+	switch status {
+	case svcsdk.LifecyclePolicyPreviewStatusInProgress, svcsdk.LifecyclePolicyPreviewStatusExpired:
+		// IN_PROGRESS needs another poll; EXPIRED needs a fresh preview
+		// started from scratch -- either way, requeue rather than leaving
+		// the policy unapplied with nothing left to retrigger it.
+		return &resource{ko}, ackrequeue.NeededAfter(nil, lifecyclePolicyPreviewRequeueInterval)
+	}
+
+	return &resource{ko}, nil
+}
+
+// countExpiringImagesByAction tallies preview results by the lifecycle rule
+// action that would apply to them.
+func countExpiringImagesByAction(results []*svcsdk.LifecyclePolicyPreviewResult) map[string]int64 {
+	counts := map[string]int64{}
+	for _, result := range results {
+		if result.Action == nil {
+			continue
+		}
+		counts[aws.StringValue(result.Action.Type)]++
+	}
+	return counts
+}
+
 // deleteLifecyclePolicy calls the DeleteLifecyclePolicy ECR API call for a
 // specific repository
 func (rm *resourceManager) deleteLifecyclePolicy(
@@ -199,7 +406,7 @@ func (rm *resourceManager) deleteLifecyclePolicy(
 		RegistryId:     dspec.RegistryID,
 	}
 
-	_, err = rm.sdkapi.DeleteLifecyclePolicy(ctx, input)
+	_, err = rm.sdkapi.DeleteLifecyclePolicyWithContext(ctx, input)
 	rm.metrics.RecordAPICall("DELETE", "DeleteLifecyclePolicy", err)
 	if err != nil {
 		return nil, err
@@ -223,11 +430,11 @@ func (rm *resourceManager) syncRepositoryTags(
 	added = append(added, updated...)
 
 	if len(removed) > 0 {
-		_, err = rm.sdkapi.UntagResource(
+		_, err = rm.sdkapi.UntagResourceWithContext(
 			ctx,
 			&svcsdk.UntagResourceInput{
 				ResourceArn: (*string)(latest.ko.Status.ACKResourceMetadata.ARN),
-				TagKeys:     aws.ToStringSlice(removed),
+				TagKeys:     removed,
 			},
 		)
 		rm.metrics.RecordAPICall("UPDATE", "UntagResource", err)
@@ -237,7 +444,7 @@ func (rm *resourceManager) syncRepositoryTags(
 	}
 
 	if len(added) > 0 {
-		_, err = rm.sdkapi.TagResource(
+		_, err = rm.sdkapi.TagResourceWithContext(
 			ctx,
 			&svcsdk.TagResourceInput{
 				ResourceArn: (*string)(latest.ko.Status.ACKResourceMetadata.ARN),
@@ -274,7 +481,7 @@ func (rm *resourceManager) updateRepositoryPolicy(
 		PolicyText:     dspec.Policy,
 	}
 
-	_, err = rm.sdkapi.SetRepositoryPolicy(ctx, input)
+	_, err = rm.sdkapi.SetRepositoryPolicyWithContext(ctx, input)
 	rm.metrics.RecordAPICall("UPDATE", "SetRepositoryPolicy", err)
 	if err != nil {
 		return nil, err
@@ -298,10 +505,165 @@ func (rm *resourceManager) deleteRepositoryPolicy(
 		RegistryId:     dspec.RegistryID,
 	}
 
-	_, err = rm.sdkapi.DeleteRepositoryPolicy(ctx, input)
+	_, err = rm.sdkapi.DeleteRepositoryPolicyWithContext(ctx, input)
 	rm.metrics.RecordAPICall("DELETE", "DeleteRepositoryPolicy", err)
 	if err != nil {
 		return nil, err
 	}
 	return desired, nil
 }
+
+// ConditionTypeImageCleanupSynced is set to True when the most recent
+// Spec.ImageRetention pruning run completed, regardless of whether any
+// images matched its rules, recording the outcome in its Message.
+const ConditionTypeImageCleanupSynced ackv1alpha1.ConditionType = "ACK.ImageCleanupSynced"
+
+// pruneImages deletes images from the repository according to
+// Spec.ImageRetention, as an alternative to ECR's own LifecyclePolicy, and
+// records the outcome in Status.ImageCleanupSummary and the
+// ConditionTypeImageCleanupSynced condition. It runs after the other
+// customUpdateRepository calls so it never races a spec change applied in
+// the same reconcile.
+//
+// Each BatchDeleteImage batch also emits a normal "ImagesPruned" Event via
+// rm.eventRecorder, in addition to the cumulative Status.ImageCleanupSummary
+// and ConditionTypeImageCleanupSynced, so per-batch deletions are visible to
+// `kubectl describe` without waiting for the whole prune run to finish.
+//
+// Both this function and customDeleteRepository's deleteAllImages need to
+// chunk image IDs to BatchDeleteImage's 100-per-call limit; they share
+// chunkImageIDs (custom_delete_api.go) for that rather than each
+// reimplementing it.
+func (rm *resourceManager) pruneImages(
+	ctx context.Context,
+	desired *resource,
+) (*resource, error) {
+	var err error
+	rlog := ackrtlog.FromContext(ctx)
+	exit := rlog.Trace("rm.pruneImages")
+	defer exit(err)
+
+	dspec := desired.ko.Spec
+	retention := dspec.ImageRetention
+
+	details, err := rm.describeAllImages(ctx, desired.ko)
+	if err != nil {
+		return nil, err
+	}
+
+	toDelete := imagesToPrune(details, retention)
+
+	ko := desired.ko.DeepCopy()
+	summary := &svcapitypes.ImageCleanupSummary{
+		LastRunAt:    &metav1.Time{Time: time.Now().UTC()},
+		DeletedCount: aws.Int64(0),
+		FailedCount:  aws.Int64(0),
+	}
+	for _, batch := range chunkImageIDs(toDelete, batchDeleteImageLimit) {
+		resp, deleteErr := rm.sdkapi.BatchDeleteImageWithContext(ctx, &svcsdk.BatchDeleteImageInput{
+			RepositoryName: dspec.Name,
+			RegistryId:     dspec.RegistryID,
+			ImageIds:       batch,
+		})
+		rm.metrics.RecordAPICall("DELETE", "BatchDeleteImage", deleteErr)
+		if deleteErr != nil {
+			return nil, deleteErr
+		}
+		*summary.DeletedCount += int64(len(resp.ImageIds))
+		*summary.FailedCount += int64(len(resp.Failures))
+		rm.eventRecorder.Eventf(
+			ko,
+			corev1.EventTypeNormal,
+			"ImagesPruned",
+			"deleted %d image(s), %d failure(s) in this batch",
+			len(resp.ImageIds), len(resp.Failures),
+		)
+	}
+	ko.Status.ImageCleanupSummary = summary
+
+	var cond *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == ConditionTypeImageCleanupSynced {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: ConditionTypeImageCleanupSynced}
+		ko.Status.Conditions = append(ko.Status.Conditions, cond)
+	}
+	cond.Status = corev1.ConditionTrue
+	msg := fmt.Sprintf("pruned %d image(s), %d failure(s)", *summary.DeletedCount, *summary.FailedCount)
+	cond.Message = &msg
+
+	return &resource{ko}, nil
+}
+
+// imagesToPrune evaluates retention against details and returns the
+// ImageIdentifiers (keyed by digest) that should be deleted. An image is
+// exempt from every rule once any of its tags matches a KeepTagPatterns
+// glob. Of the remainder, untagged images beyond MaxUntaggedImages (oldest
+// first) are pruned, and any image older than MaxImageAgeDays is pruned.
+func imagesToPrune(
+	details []*svcsdk.ImageDetail,
+	retention *svcapitypes.ImageRetention,
+) []*svcsdk.ImageIdentifier {
+	var toDelete []*svcsdk.ImageIdentifier
+	seen := map[string]bool{}
+	addForDeletion := func(detail *svcsdk.ImageDetail) {
+		if detail.ImageDigest == nil || seen[*detail.ImageDigest] {
+			return
+		}
+		seen[*detail.ImageDigest] = true
+		toDelete = append(toDelete, &svcsdk.ImageIdentifier{ImageDigest: detail.ImageDigest})
+	}
+
+	var untagged []*svcsdk.ImageDetail
+	for _, detail := range details {
+		if matchesKeepTagPatterns(detail.ImageTags, retention.KeepTagPatterns) {
+			continue
+		}
+		if retention.MaxImageAgeDays != nil && detail.ImagePushedAt != nil {
+			cutoff := time.Now().AddDate(0, 0, -int(*retention.MaxImageAgeDays))
+			if detail.ImagePushedAt.Before(cutoff) {
+				addForDeletion(detail)
+				continue
+			}
+		}
+		if len(detail.ImageTags) == 0 {
+			untagged = append(untagged, detail)
+		}
+	}
+
+	if retention.MaxUntaggedImages != nil {
+		sort.SliceStable(untagged, func(i, j int) bool {
+			if untagged[i].ImagePushedAt == nil || untagged[j].ImagePushedAt == nil {
+				return untagged[j].ImagePushedAt == nil && untagged[i].ImagePushedAt != nil
+			}
+			return untagged[i].ImagePushedAt.After(*untagged[j].ImagePushedAt)
+		})
+		if max := int(*retention.MaxUntaggedImages); len(untagged) > max {
+			for _, detail := range untagged[max:] {
+				addForDeletion(detail)
+			}
+		}
+	}
+
+	return toDelete
+}
+
+// matchesKeepTagPatterns reports whether any of tags matches any of
+// patterns, interpreted as filepath.Match globs.
+func matchesKeepTagPatterns(tags []*string, patterns []*string) bool {
+	for _, pattern := range patterns {
+		if pattern == nil {
+			continue
+		}
+		for _, tag := range tags {
+			if matched, err := filepath.Match(*pattern, aws.StringValue(tag)); err == nil && matched {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -2,16 +2,26 @@ package repository
 
 import (
 	"context"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
 	"strconv"
+	"strings"
+	"time"
 
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
 	ackutil "github.com/aws-controllers-k8s/runtime/pkg/util"
+	"github.com/aws/aws-sdk-go/aws"
 	svcsdk "github.com/aws/aws-sdk-go/service/ecr"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	svcapitypes "github.com/aws-controllers-k8s/ecr-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/ecr-controller/pkg/policy"
 )
 
 const (
@@ -21,10 +31,64 @@ const (
 	// AnnotationDeleteForce is an annotation whose value indicates whether
 	// the repository should be removed if it contains images.
 	AnnotationDeleteForce = AnnotationPrefix + "force-delete"
+	// AnnotationScanFindingsRefreshInterval is an annotation whose value
+	// indicates the minimum duration (as parsed by time.ParseDuration)
+	// between calls to DescribeImageScanFindings made to populate
+	// Status.ScanFindingsSummary. This lets users trade result freshness
+	// for API-call cost.
+	AnnotationScanFindingsRefreshInterval = AnnotationPrefix + "scan-findings-refresh-interval"
+	// AnnotationScanFindingsSeverityThreshold is an annotation whose value
+	// is the minimum finding severity (CRITICAL/HIGH/MEDIUM/LOW/
+	// INFORMATIONAL/UNDEFINED) that causes the ScanFindingsAboveThreshold
+	// condition to be set.
+	AnnotationScanFindingsSeverityThreshold = AnnotationPrefix + "scan-findings-severity-threshold"
 
-	DefaultDeleteForce = false
+	DefaultDeleteForce                   = false
+	DefaultScanFindingsRefreshInterval   = 1 * time.Hour
+	DefaultScanFindingsSeverityThreshold = "CRITICAL"
+	// DefaultImageObservationMaxCount is how many entries are kept in
+	// Status.ObservedImages when Spec.ImageObservation.MaxObservedImages is
+	// unset.
+	DefaultImageObservationMaxCount = 20
+	// DefaultImageObservationTagFilterType is how
+	// Spec.ImageObservation.TagFilter is interpreted when
+	// Spec.ImageObservation.TagFilterType is unset.
+	DefaultImageObservationTagFilterType = "GLOB"
+	// ConditionTypeScanFindingsAboveThreshold is set to True when the most
+	// recent scan found one or more findings at or above the configured
+	// severity threshold.
+	ConditionTypeScanFindingsAboveThreshold ackv1alpha1.ConditionType = "ACK.ScanFindingsAboveThreshold"
+	// maxScanFindings caps how many individual findings are copied into
+	// Status.ScanFindingsSummary.Findings.
+	maxScanFindings = 10
+	// ConditionTypePolicyTagConditionMismatch is set to True when
+	// Spec.Policy references a tag key, via an aws:ResourceTag/
+	// aws:RequestTag Condition, that isn't declared in Spec.Tags.
+	ConditionTypePolicyTagConditionMismatch ackv1alpha1.ConditionType = "ACK.PolicyTagConditionMismatch"
+	// ConditionTypeSignatureTagPresence is set to True when every image tag
+	// has a matching cosign signature tag and Spec.SignaturePolicy declares
+	// at least one PublicKeyRef signer, and False (with the tags missing one
+	// named in the message) otherwise. This is presence of a
+	// ".sig"-suffixed tag, not a verified signature -- see SignaturePolicy's
+	// doc comment.
+	ConditionTypeSignatureTagPresence ackv1alpha1.ConditionType = "ACK.SignatureTagPresence"
+	// cosignSignatureTagSuffix is the tag suffix cosign uses to store an
+	// image's signature artifact, derived from its manifest digest (e.g.
+	// "sha256-<hex>.sig" for a "sha256:<hex>" digest).
+	cosignSignatureTagSuffix = ".sig"
 )
 
+// severityRank orders finding severities from least to most severe so the
+// configured threshold can be compared against a scan's findings.
+var severityRank = map[string]int{
+	"INFORMATIONAL": 0,
+	"UNDEFINED":     1,
+	"LOW":           2,
+	"MEDIUM":        3,
+	"HIGH":          4,
+	"CRITICAL":      5,
+}
+
 // GetDeleteForce returns whether the repository should be deleted if it
 // contains images as determined by the annotation on the object, or the
 // default value otherwise.
@@ -45,6 +109,45 @@ func GetDeleteForce(
 	return deleteForceBool
 }
 
+// GetScanFindingsRefreshInterval returns the minimum duration between
+// refreshes of Status.ScanFindingsSummary, as determined by the
+// AnnotationScanFindingsRefreshInterval annotation on the object, or the
+// default value otherwise.
+func GetScanFindingsRefreshInterval(
+	m *metav1.ObjectMeta,
+) time.Duration {
+	resAnnotations := m.GetAnnotations()
+	raw, ok := resAnnotations[AnnotationScanFindingsRefreshInterval]
+	if !ok {
+		return DefaultScanFindingsRefreshInterval
+	}
+
+	interval, err := time.ParseDuration(raw)
+	if err != nil {
+		return DefaultScanFindingsRefreshInterval
+	}
+
+	return interval
+}
+
+// GetScanFindingsSeverityThreshold returns the minimum finding severity
+// that causes ConditionTypeScanFindingsAboveThreshold to be raised, as
+// determined by the AnnotationScanFindingsSeverityThreshold annotation on
+// the object, or the default value otherwise.
+func GetScanFindingsSeverityThreshold(
+	m *metav1.ObjectMeta,
+) string {
+	resAnnotations := m.GetAnnotations()
+	threshold, ok := resAnnotations[AnnotationScanFindingsSeverityThreshold]
+	if !ok {
+		return DefaultScanFindingsSeverityThreshold
+	}
+	if _, known := severityRank[threshold]; !known {
+		return DefaultScanFindingsSeverityThreshold
+	}
+	return threshold
+}
+
 // setResourceAdditionalFields will describe the fields that are not return by
 // DescribeRepository calls
 func (rm *resourceManager) setResourceAdditionalFields(
@@ -70,10 +173,560 @@ func (rm *resourceManager) setResourceAdditionalFields(
 	if err != nil {
 		return err
 	}
+	// Flag policy Condition blocks that reference tag keys not declared in
+	// Spec.Tags -- ECR will never be able to satisfy such a condition.
+	rm.validatePolicyTagConditions(ko)
+	// Set the scan findings summary, throttled by
+	// AnnotationScanFindingsRefreshInterval so this doesn't turn every
+	// reconcile into a DescribeImageScanFindings call.
+	if rm.shouldRefreshScanFindings(ko) {
+		err = rm.setScanFindingsSummary(ctx, ko)
+		if err != nil {
+			return err
+		}
+	}
+	// Set the observed image digests, when opted in via Spec.ImageObservation.
+	// customUpdateRepository populates this too, so that newly pushed images
+	// are picked up on the periodic requeue it schedules, not just when the
+	// spec changes.
+	if err = rm.setObservedImages(ctx, ko); err != nil {
+		return err
+	}
+	// Record signature tag presence, when opted in via Spec.SignaturePolicy.
+	// See setSignatureTagPresence's doc comment for why this never deletes
+	// anything.
+	if err = rm.setSignatureTagPresence(ctx, ko); err != nil {
+		return err
+	}
 
 	return nil
 }
 
+// shouldRefreshScanFindings returns true if Status.ScanFindingsSummary
+// hasn't been refreshed within GetScanFindingsRefreshInterval. The
+// timestamp is tracked in Status.ScanFindingsRefreshedAt rather than an
+// annotation: ACK patches the object's status subresource on every
+// reconcile, but it doesn't patch arbitrary ObjectMeta.Annotations
+// mutations made from the read path, so an annotation written here would
+// never actually persist.
+func (rm *resourceManager) shouldRefreshScanFindings(
+	ko *svcapitypes.Repository,
+) bool {
+	if ko.Status.ScanFindingsRefreshedAt == nil {
+		return true
+	}
+	return time.Since(ko.Status.ScanFindingsRefreshedAt.Time) >= GetScanFindingsRefreshInterval(&ko.ObjectMeta)
+}
+
+// setScanFindingsSummary calls DescribeImageScanFindings for the image tag
+// Spec.ScanReportImageTags names (or the most recently pushed image, when
+// unset) and records the result on Status.ScanFindingsSummary.
+func (rm *resourceManager) setScanFindingsSummary(
+	ctx context.Context,
+	ko *svcapitypes.Repository,
+) error {
+	imageID, err := rm.mostRecentImageID(ctx, ko)
+	if err != nil {
+		return err
+	}
+	if imageID == nil {
+		return nil
+	}
+
+	resp, err := rm.sdkapi.DescribeImageScanFindingsWithContext(
+		ctx,
+		&svcsdk.DescribeImageScanFindingsInput{
+			RepositoryName: ko.Spec.Name,
+			RegistryId:     ko.Spec.RegistryID,
+			ImageId:        imageID,
+		},
+	)
+	rm.metrics.RecordAPICall("GET", "DescribeImageScanFindings", err)
+	if err != nil {
+		if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == svcsdk.ErrCodeScanNotFoundException {
+			ko.Status.ScanFindingsSummary = nil
+			ko.Status.ScanFindingsRefreshedAt = &metav1.Time{Time: time.Now().UTC()}
+			return nil
+		}
+		return err
+	}
+
+	var scanStatus *string
+	if resp.ImageScanStatus != nil {
+		scanStatus = resp.ImageScanStatus.Status
+	}
+	summary := &svcapitypes.ImageScanFindingsSummary{
+		ImageTag:    imageID.ImageTag,
+		ImageDigest: imageID.ImageDigest,
+		ScanStatus:  scanStatus,
+	}
+	if resp.ImageScanFindings != nil {
+		if resp.ImageScanFindings.ImageScanCompletedAt != nil {
+			summary.CompletedAt = &metav1.Time{Time: *resp.ImageScanFindings.ImageScanCompletedAt}
+		}
+		if len(resp.ImageScanFindings.FindingSeverityCounts) > 0 {
+			summary.SeverityCounts = make(map[string]*int64, len(resp.ImageScanFindings.FindingSeverityCounts))
+			for severity, count := range resp.ImageScanFindings.FindingSeverityCounts {
+				c := *count
+				summary.SeverityCounts[severity] = &c
+			}
+		}
+		summary.Findings = topImageScanFindings(resp.ImageScanFindings.Findings, maxScanFindings)
+	}
+
+	ko.Status.ScanFindingsSummary = summary
+	ko.Status.ScanFindingsRefreshedAt = &metav1.Time{Time: time.Now().UTC()}
+
+	rm.setScanFindingsCondition(ko, summary)
+
+	return nil
+}
+
+// setObservedImages populates Status.ObservedImages via DescribeImages when
+// Spec.ImageObservation is set, recording each matching tag alongside the
+// immutable digest, pushed-at timestamp, size and artifact media type it
+// currently resolves to -- so GitOps consumers can pin Deployments to
+// digests without running a separate image reflector.
+func (rm *resourceManager) setObservedImages(
+	ctx context.Context,
+	ko *svcapitypes.Repository,
+) error {
+	if ko.Spec.ImageObservation == nil {
+		ko.Status.ObservedImages = nil
+		return nil
+	}
+
+	details, err := rm.describeAllImages(ctx, ko)
+	if err != nil {
+		return err
+	}
+
+	var observed []*svcapitypes.ObservedImage
+	for _, detail := range details {
+		for _, tag := range detail.ImageTags {
+			if tag == nil || !matchesTagFilter(*tag, ko.Spec.ImageObservation) {
+				continue
+			}
+			img := &svcapitypes.ObservedImage{
+				Tag:               tag,
+				Digest:            detail.ImageDigest,
+				SizeBytes:         detail.ImageSizeInBytes,
+				ArtifactMediaType: detail.ArtifactMediaType,
+			}
+			if detail.ImagePushedAt != nil {
+				img.PushedAt = &metav1.Time{Time: *detail.ImagePushedAt}
+			}
+			observed = append(observed, img)
+		}
+	}
+
+	sort.SliceStable(observed, func(i, j int) bool {
+		if observed[i].PushedAt == nil || observed[j].PushedAt == nil {
+			return observed[j].PushedAt == nil && observed[i].PushedAt != nil
+		}
+		return observed[i].PushedAt.After(observed[j].PushedAt.Time)
+	})
+
+	if max := GetImageObservationMaxCount(ko.Spec.ImageObservation); int64(len(observed)) > max {
+		observed = observed[:max]
+	}
+
+	ko.Status.ObservedImages = observed
+	return nil
+}
+
+// describeAllImages returns every image in the repository, paginating
+// through DescribeImages via NextToken. A single unpaginated call only
+// returns its first, arbitrarily-ordered page, which would silently drop
+// images from callers that need the complete set (or the true newest
+// push) to be correct.
+func (rm *resourceManager) describeAllImages(
+	ctx context.Context,
+	ko *svcapitypes.Repository,
+) ([]*svcsdk.ImageDetail, error) {
+	var details []*svcsdk.ImageDetail
+	var nextToken *string
+	for {
+		resp, err := rm.sdkapi.DescribeImagesWithContext(
+			ctx,
+			&svcsdk.DescribeImagesInput{
+				RepositoryName: ko.Spec.Name,
+				RegistryId:     ko.Spec.RegistryID,
+				NextToken:      nextToken,
+			},
+		)
+		rm.metrics.RecordAPICall("READ_MANY", "DescribeImages", err)
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, resp.ImageDetails...)
+		if resp.NextToken == nil {
+			break
+		}
+		nextToken = resp.NextToken
+	}
+	return details, nil
+}
+
+// GetImageObservationMaxCount returns the maximum number of entries kept in
+// Status.ObservedImages, as determined by
+// Spec.ImageObservation.MaxObservedImages, or
+// DefaultImageObservationMaxCount otherwise.
+func GetImageObservationMaxCount(obs *svcapitypes.ImageObservation) int64 {
+	if obs == nil || obs.MaxObservedImages == nil {
+		return DefaultImageObservationMaxCount
+	}
+	return *obs.MaxObservedImages
+}
+
+// matchesTagFilter reports whether tag should be recorded in
+// Status.ObservedImages, as determined by Spec.ImageObservation.TagFilter,
+// interpreted according to TagFilterType ("GLOB", the default, or
+// "REGEXP"). An empty TagFilter matches every tag. A malformed pattern
+// matches nothing, rather than falling back to matching everything.
+func matchesTagFilter(tag string, obs *svcapitypes.ImageObservation) bool {
+	if obs.TagFilter == nil || *obs.TagFilter == "" {
+		return true
+	}
+	filterType := DefaultImageObservationTagFilterType
+	if obs.TagFilterType != nil {
+		filterType = *obs.TagFilterType
+	}
+	switch filterType {
+	case "REGEXP":
+		re, err := regexp.Compile(*obs.TagFilter)
+		if err != nil {
+			return false
+		}
+		return re.MatchString(tag)
+	default:
+		matched, err := filepath.Match(*obs.TagFilter, tag)
+		if err != nil {
+			return false
+		}
+		return matched
+	}
+}
+
+// setSignatureTagPresence checks Spec.SignaturePolicy's "does this image
+// have a signature tag" check and records the outcome via
+// ConditionTypeSignatureTagPresence. This never deletes anything -- see
+// SignaturePolicy's doc comment for why: this is a presence check, not
+// cryptographic verification, so it's never a safe basis for
+// BatchDeleteImage.
+//
+// Only signature *presence* (a cosign signature artifact exists for the
+// image's digest) is checked by this controller; verifying the signature
+// against a Signer's public key, and keyless Fulcio/OIDC identity
+// verification, both require fetching the artifact via BatchGetImage and
+// linking the sigstore verification libraries, neither of which this
+// controller does -- see SignaturePolicy's doc comment. An image is
+// therefore only reported as having a signature tag when it has a
+// signature artifact AND Spec.SignaturePolicy declares at least one
+// PublicKeyRef signer; a policy that only declares Fulcio/OIDC signers
+// can never be satisfied, and every image is reported as missing one.
+func (rm *resourceManager) setSignatureTagPresence(
+	ctx context.Context,
+	ko *svcapitypes.Repository,
+) error {
+	sp := ko.Spec.SignaturePolicy
+	if sp == nil {
+		return nil
+	}
+
+	missing, err := rm.nonCompliantImageTags(ctx, ko, sp)
+	if err != nil {
+		return err
+	}
+
+	var cond *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == ConditionTypeSignatureTagPresence {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: ConditionTypeSignatureTagPresence}
+		ko.Status.Conditions = append(ko.Status.Conditions, cond)
+	}
+	if len(missing) == 0 {
+		cond.Status = corev1.ConditionTrue
+		cond.Message = nil
+		return nil
+	}
+	cond.Status = corev1.ConditionFalse
+	msg := fmt.Sprintf("image tag(s) missing a signature tag: %s", strings.Join(missing, ", "))
+	cond.Message = &msg
+	return nil
+}
+
+// nonCompliantImageTags lists the image tags in the repository that don't
+// have a matching signature tag: a cosign signature artifact must exist
+// for the image's digest, and sp must declare at least one PublicKeyRef
+// signer.
+//
+// Cosign signature artifacts themselves (tagged "sha256-<hex>.sig") are
+// excluded from the result -- they have no signature of their own, and
+// flagging them would be misleading.
+func (rm *resourceManager) nonCompliantImageTags(
+	ctx context.Context,
+	ko *svcapitypes.Repository,
+	sp *svcapitypes.SignaturePolicy,
+) ([]string, error) {
+	hasKeySigner := false
+	for _, signer := range sp.Signers {
+		if signer.PublicKeyRef != nil {
+			hasKeySigner = true
+			break
+		}
+	}
+
+	details, err := rm.describeAllImages(ctx, ko)
+	if err != nil {
+		return nil, err
+	}
+
+	var nonCompliant []string
+	for _, detail := range details {
+		if isSignatureArtifact(detail) {
+			continue
+		}
+		sigTag, ok := cosignSignatureTag(detail.ImageDigest)
+		if !ok {
+			continue
+		}
+		signed, err := rm.hasImageTag(ctx, ko, sigTag)
+		if err != nil {
+			return nil, err
+		}
+		if signed && hasKeySigner {
+			continue
+		}
+		for _, tag := range detail.ImageTags {
+			nonCompliant = append(nonCompliant, aws.StringValue(tag))
+		}
+	}
+	return nonCompliant, nil
+}
+
+// isSignatureArtifact reports whether detail is itself a cosign signature
+// artifact (i.e. tagged "sha256-<hex>.sig"), rather than an image that may
+// have one.
+func isSignatureArtifact(detail *svcsdk.ImageDetail) bool {
+	for _, tag := range detail.ImageTags {
+		if strings.HasSuffix(aws.StringValue(tag), cosignSignatureTagSuffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// cosignSignatureTag derives the tag cosign stores an image's signature
+// artifact under from its manifest digest, e.g. "sha256-<hex>.sig" for a
+// "sha256:<hex>" digest.
+func cosignSignatureTag(digest *string) (string, bool) {
+	const prefix = "sha256:"
+	if digest == nil || !strings.HasPrefix(*digest, prefix) {
+		return "", false
+	}
+	return "sha256-" + strings.TrimPrefix(*digest, prefix) + cosignSignatureTagSuffix, true
+}
+
+// hasImageTag reports whether tag currently resolves to an image in the
+// repository.
+func (rm *resourceManager) hasImageTag(
+	ctx context.Context,
+	ko *svcapitypes.Repository,
+	tag string,
+) (bool, error) {
+	_, err := rm.sdkapi.DescribeImagesWithContext(
+		ctx,
+		&svcsdk.DescribeImagesInput{
+			RepositoryName: ko.Spec.Name,
+			RegistryId:     ko.Spec.RegistryID,
+			ImageIds:       []*svcsdk.ImageIdentifier{{ImageTag: &tag}},
+		},
+	)
+	rm.metrics.RecordAPICall("READ_MANY", "DescribeImages", err)
+	if err != nil {
+		if awsErr, ok := ackerr.AWSError(err); ok && awsErr.Code() == svcsdk.ErrCodeImageNotFoundException {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// mostRecentImageID resolves the image that Status.ScanFindingsSummary
+// should describe: the first tag in Spec.ScanReportImageTags, or -- when
+// that's unset -- the most recently pushed image in the repository.
+func (rm *resourceManager) mostRecentImageID(
+	ctx context.Context,
+	ko *svcapitypes.Repository,
+) (*svcsdk.ImageIdentifier, error) {
+	if len(ko.Spec.ScanReportImageTags) > 0 {
+		return &svcsdk.ImageIdentifier{ImageTag: ko.Spec.ScanReportImageTags[0]}, nil
+	}
+
+	details, err := rm.describeAllImages(ctx, ko)
+	if err != nil {
+		return nil, err
+	}
+	if len(details) == 0 {
+		return nil, nil
+	}
+
+	newest := details[0]
+	for _, detail := range details[1:] {
+		if detail.ImagePushedAt != nil && (newest.ImagePushedAt == nil || detail.ImagePushedAt.After(*newest.ImagePushedAt)) {
+			newest = detail
+		}
+	}
+	id := &svcsdk.ImageIdentifier{ImageDigest: newest.ImageDigest}
+	if len(newest.ImageTags) > 0 {
+		id.ImageTag = newest.ImageTags[0]
+	}
+	return id, nil
+}
+
+// topImageScanFindings returns the n most severe findings.
+func topImageScanFindings(findings []*svcsdk.ImageScanFinding, n int) []*svcapitypes.ImageScanFinding {
+	sorted := make([]*svcsdk.ImageScanFinding, len(findings))
+	copy(sorted, findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return severityRank[aws.StringValue(sorted[i].Severity)] > severityRank[aws.StringValue(sorted[j].Severity)]
+	})
+	if len(sorted) > n {
+		sorted = sorted[:n]
+	}
+
+	out := make([]*svcapitypes.ImageScanFinding, 0, len(sorted))
+	for _, f := range sorted {
+		out = append(out, &svcapitypes.ImageScanFinding{
+			Name:     f.Name,
+			Severity: f.Severity,
+			URI:      findingURI(f),
+		})
+	}
+	return out
+}
+
+// findingURI returns the CVE-style URI ECR attaches to a finding, if any.
+func findingURI(f *svcsdk.ImageScanFinding) *string {
+	return f.Uri
+}
+
+// setScanFindingsCondition raises or clears
+// ConditionTypeScanFindingsAboveThreshold based on whether any finding in
+// summary meets or exceeds GetScanFindingsSeverityThreshold.
+func (rm *resourceManager) setScanFindingsCondition(
+	ko *svcapitypes.Repository,
+	summary *svcapitypes.ImageScanFindingsSummary,
+) {
+	threshold := severityRank[GetScanFindingsSeverityThreshold(&ko.ObjectMeta)]
+	aboveThreshold := false
+	for severity, count := range summary.SeverityCounts {
+		if count != nil && *count > 0 && severityRank[severity] >= threshold {
+			aboveThreshold = true
+			break
+		}
+	}
+
+	var cond *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == ConditionTypeScanFindingsAboveThreshold {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: ConditionTypeScanFindingsAboveThreshold}
+		ko.Status.Conditions = append(ko.Status.Conditions, cond)
+	}
+	if aboveThreshold {
+		cond.Status = corev1.ConditionTrue
+		msg := "most recent image scan reported findings at or above the configured severity threshold"
+		cond.Message = &msg
+	} else {
+		cond.Status = corev1.ConditionFalse
+		cond.Message = nil
+	}
+}
+
+// validatePolicyTagConditions raises ConditionTypePolicyTagConditionMismatch
+// when Spec.Policy contains a StringEquals/StringLike Condition on
+// aws:ResourceTag/<k> or aws:RequestTag/<k> for a tag key that isn't
+// declared in Spec.Tags. A policy can't be satisfied against a tag the
+// repository doesn't have, so this is surfaced rather than silently
+// applied.
+func (rm *resourceManager) validatePolicyTagConditions(
+	ko *svcapitypes.Repository,
+) {
+	if ko.Spec.Policy == nil || *ko.Spec.Policy == "" {
+		rm.clearPolicyTagMismatchCondition(ko)
+		return
+	}
+
+	referenced, err := policy.ReferencedTagKeys(*ko.Spec.Policy)
+	if err != nil {
+		// Malformed policy text isn't this hook's concern -- SetRepositoryPolicy
+		// will reject it.
+		rm.clearPolicyTagMismatchCondition(ko)
+		return
+	}
+
+	declared := map[string]bool{}
+	for _, tag := range ko.Spec.Tags {
+		if tag.Key != nil {
+			declared[*tag.Key] = true
+		}
+	}
+
+	var missing []string
+	for _, key := range referenced {
+		if !declared[key] {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) == 0 {
+		rm.clearPolicyTagMismatchCondition(ko)
+		return
+	}
+
+	var cond *ackv1alpha1.Condition
+	for _, c := range ko.Status.Conditions {
+		if c.Type == ConditionTypePolicyTagConditionMismatch {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: ConditionTypePolicyTagConditionMismatch}
+		ko.Status.Conditions = append(ko.Status.Conditions, cond)
+	}
+	cond.Status = corev1.ConditionTrue
+	msg := fmt.Sprintf(
+		"repository policy references tag key(s) %s via aws:ResourceTag/aws:RequestTag conditions that are not present in spec.tags",
+		strings.Join(missing, ", "),
+	)
+	cond.Message = &msg
+}
+
+// clearPolicyTagMismatchCondition clears ConditionTypePolicyTagConditionMismatch
+// if it was previously set by validatePolicyTagConditions.
+func (rm *resourceManager) clearPolicyTagMismatchCondition(
+	ko *svcapitypes.Repository,
+) {
+	for _, c := range ko.Status.Conditions {
+		if c.Type == ConditionTypePolicyTagConditionMismatch {
+			c.Status = corev1.ConditionFalse
+			c.Message = nil
+			return
+		}
+	}
+}
+
 // getRepositoryPolicy retrieves a repository permissions policy.
 func (rm *resourceManager) getRepositoryPolicy(
 	ctx context.Context,
@@ -101,7 +754,7 @@ func (rm *resourceManager) getRepositoryPolicy(
 		// do not return an error if the repository policy is not found. Simply return an empty policy.
 		return nil, nil
 	}
-	return getRepositoryPolicyResponse.PolicyText, nil
+	return canonicalizePolicyText(getRepositoryPolicyResponse.PolicyText), nil
 }
 
 // getRepositoryLifecyclePolicy retrieves a repository lifecycle policy.
@@ -131,7 +784,24 @@ func (rm *resourceManager) getRepositoryLifecyclePolicy(
 		// do not return an error if the lifecycle policy is not found. Simply return an empty lifecycle policy.
 		return nil, nil
 	}
-	return getLifecyclePolicyResponse.LifecyclePolicyText, nil
+	return canonicalizePolicyText(getLifecyclePolicyResponse.LifecyclePolicyText), nil
+}
+
+// canonicalizePolicyText canonicalizes a policy/lifecycle policy document
+// read back from the API, so that repeated reconciles observe a stable
+// Spec.Policy/Spec.LifecyclePolicy value instead of churning on ECR's own
+// server-side JSON normalization. Falls back to the raw text if it doesn't
+// parse as JSON, which equalPolicyText also falls back to a literal
+// comparison for.
+func canonicalizePolicyText(text *string) *string {
+	if text == nil {
+		return nil
+	}
+	canonical, err := policy.Canonicalize(*text)
+	if err != nil {
+		return text
+	}
+	return &canonical
 }
 
 // getRepositoryTags retrieves a resource list of tags.
@@ -168,6 +838,29 @@ func customPreCompare(
 			delta.Add("Spec.Tags", a.ko.Spec.Tags, b.ko.Spec.Tags)
 		}
 	}
+	if !equalPolicyText(a.ko.Spec.Policy, b.ko.Spec.Policy) {
+		delta.Add("Spec.Policy", a.ko.Spec.Policy, b.ko.Spec.Policy)
+	}
+	if !equalPolicyText(a.ko.Spec.LifecyclePolicy, b.ko.Spec.LifecyclePolicy) {
+		delta.Add("Spec.LifecyclePolicy", a.ko.Spec.LifecyclePolicy, b.ko.Spec.LifecyclePolicy)
+	}
+}
+
+// equalPolicyText reports whether two repository or lifecycle policy
+// documents are semantically equal by comparing their canonicalized JSON,
+// so that a cosmetic reformatting of policy text (key order, whitespace)
+// doesn't cause an unnecessary Put*Policy call. Falls back to a literal
+// string comparison if either document fails to parse as JSON.
+func equalPolicyText(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	ca, errA := policy.Canonicalize(*a)
+	cb, errB := policy.Canonicalize(*b)
+	if errA != nil || errB != nil {
+		return *a == *b
+	}
+	return ca == cb
 }
 
 // equalTags returns true if two Tag arrays are equal regardless of the order
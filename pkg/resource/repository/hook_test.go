@@ -15,7 +15,9 @@ package repository_test
 
 import (
 	"testing"
+	"time"
 
+	svcapitypes "github.com/aws-controllers-k8s/ecr-controller/apis/v1alpha1"
 	repo "github.com/aws-controllers-k8s/ecr-controller/pkg/resource/repository"
 	"github.com/stretchr/testify/assert"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,3 +44,57 @@ func Test_GetDeleteForce(t *testing.T) {
 	assert.Equal(repo.GetDeleteForce(&badAnnotation), repo.DefaultDeleteForce)
 	assert.Equal(repo.GetDeleteForce(&validAnnotation), true)
 }
+
+func Test_GetScanFindingsRefreshInterval(t *testing.T) {
+	assert := assert.New(t)
+
+	noAnnotation := metav1.ObjectMeta{
+		Annotations: map[string]string{},
+	}
+	badAnnotation := metav1.ObjectMeta{
+		Annotations: map[string]string{
+			repo.AnnotationScanFindingsRefreshInterval: "not-a-duration",
+		},
+	}
+	validAnnotation := metav1.ObjectMeta{
+		Annotations: map[string]string{
+			repo.AnnotationScanFindingsRefreshInterval: "15m",
+		},
+	}
+
+	assert.Equal(repo.DefaultScanFindingsRefreshInterval, repo.GetScanFindingsRefreshInterval(&noAnnotation))
+	assert.Equal(repo.DefaultScanFindingsRefreshInterval, repo.GetScanFindingsRefreshInterval(&badAnnotation))
+	assert.Equal(15*time.Minute, repo.GetScanFindingsRefreshInterval(&validAnnotation))
+}
+
+func Test_GetScanFindingsSeverityThreshold(t *testing.T) {
+	assert := assert.New(t)
+
+	noAnnotation := metav1.ObjectMeta{
+		Annotations: map[string]string{},
+	}
+	unknownAnnotation := metav1.ObjectMeta{
+		Annotations: map[string]string{
+			repo.AnnotationScanFindingsSeverityThreshold: "NOT_A_SEVERITY",
+		},
+	}
+	validAnnotation := metav1.ObjectMeta{
+		Annotations: map[string]string{
+			repo.AnnotationScanFindingsSeverityThreshold: "HIGH",
+		},
+	}
+
+	assert.Equal(repo.DefaultScanFindingsSeverityThreshold, repo.GetScanFindingsSeverityThreshold(&noAnnotation))
+	assert.Equal(repo.DefaultScanFindingsSeverityThreshold, repo.GetScanFindingsSeverityThreshold(&unknownAnnotation))
+	assert.Equal("HIGH", repo.GetScanFindingsSeverityThreshold(&validAnnotation))
+}
+
+func Test_GetImageObservationMaxCount(t *testing.T) {
+	assert := assert.New(t)
+
+	max := int64(5)
+
+	assert.Equal(int64(repo.DefaultImageObservationMaxCount), repo.GetImageObservationMaxCount(nil))
+	assert.Equal(int64(repo.DefaultImageObservationMaxCount), repo.GetImageObservationMaxCount(&svcapitypes.ImageObservation{}))
+	assert.Equal(max, repo.GetImageObservationMaxCount(&svcapitypes.ImageObservation{MaxObservedImages: &max}))
+}
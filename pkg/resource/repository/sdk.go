@@ -19,9 +19,9 @@ import (
 	"context"
 	"strings"
 
-	ackv1alpha1 "github.com/aws/aws-controllers-k8s/apis/core/v1alpha1"
-	ackcompare "github.com/aws/aws-controllers-k8s/pkg/compare"
-	ackerr "github.com/aws/aws-controllers-k8s/pkg/errors"
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
 	"github.com/aws/aws-sdk-go/aws"
 	svcsdk "github.com/aws/aws-sdk-go/service/ecr"
 	corev1 "k8s.io/api/core/v1"
@@ -232,9 +232,9 @@ func (rm *resourceManager) sdkUpdate(
 	ctx context.Context,
 	desired *resource,
 	latest *resource,
-	diffReporter *ackcompare.Reporter,
+	delta *ackcompare.Delta,
 ) (*resource, error) {
-	return rm.customUpdateRepository(ctx, desired, latest, diffReporter)
+	return rm.customUpdateRepository(ctx, desired, latest, delta)
 }
 
 // sdkDelete deletes the supplied resource in the backend AWS service API
@@ -242,13 +242,7 @@ func (rm *resourceManager) sdkDelete(
 	ctx context.Context,
 	r *resource,
 ) error {
-	input, err := rm.newDeleteRequestPayload(r)
-	if err != nil {
-		return err
-	}
-	_, respErr := rm.sdkapi.DeleteRepositoryWithContext(ctx, input)
-	rm.metrics.RecordAPICall("DELETE", "DeleteRepository", respErr)
-	return respErr
+	return rm.customDeleteRepository(ctx, r)
 }
 
 // newDeleteRequestPayload returns an SDK-specific struct for the HTTP request
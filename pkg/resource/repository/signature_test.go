@@ -0,0 +1,181 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	svcsdk "github.com/aws/aws-sdk-go/service/ecr"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/ecr-controller/apis/v1alpha1"
+)
+
+func imageDetail(tags ...string) *svcsdk.ImageDetail {
+	digest := "sha256:abc"
+	tagPtrs := make([]*string, len(tags))
+	for i := range tags {
+		tagPtrs[i] = &tags[i]
+	}
+	return &svcsdk.ImageDetail{ImageDigest: &digest, ImageTags: tagPtrs}
+}
+
+func Test_cosignSignatureTag(t *testing.T) {
+	assert := assert.New(t)
+
+	digest := "sha256:abc"
+	tag, ok := cosignSignatureTag(&digest)
+	assert.True(ok)
+	assert.Equal("sha256-abc.sig", tag)
+
+	_, ok = cosignSignatureTag(nil)
+	assert.False(ok)
+
+	other := "sha512:abc"
+	_, ok = cosignSignatureTag(&other)
+	assert.False(ok)
+}
+
+func Test_isSignatureArtifact(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.True(isSignatureArtifact(imageDetail("sha256-abc.sig")))
+	assert.False(isSignatureArtifact(imageDetail("v1.0.0")))
+}
+
+func Test_hasImageTag(t *testing.T) {
+	assert := assert.New(t)
+
+	mock := &mockECRAPI{signatureTags: map[string]bool{"sha256-abc.sig": true}}
+	rm := &resourceManager{sdkapi: mock, metrics: ackmetrics.NewMetrics("ecr")}
+
+	signed, err := rm.hasImageTag(context.Background(), testResource(), "sha256-abc.sig")
+	assert.NoError(err)
+	assert.True(signed)
+
+	signed, err = rm.hasImageTag(context.Background(), testResource(), "sha256-missing.sig")
+	assert.NoError(err)
+	assert.False(signed)
+}
+
+func Test_nonCompliantImageTags(t *testing.T) {
+	assert := assert.New(t)
+
+	sp := &svcapitypes.SignaturePolicy{
+		Signers: []*svcapitypes.SignerIdentity{{PublicKeyRef: &ackv1alpha1.SecretKeyReference{}}},
+	}
+	// "unsigned" gets its own digest, whose signature tag doesn't exist.
+	unsigned := imageDetail("unsigned")
+	unsignedDigest := "sha256:def"
+	unsigned.ImageDigest = &unsignedDigest
+	mock := &mockECRAPI{
+		describeImagesPages: []*svcsdk.DescribeImagesOutput{{
+			ImageDetails: []*svcsdk.ImageDetail{
+				imageDetail("signed"),
+				unsigned,
+				imageDetail("sha256-abc.sig"),
+			},
+		}},
+		signatureTags: map[string]bool{"sha256-abc.sig": true},
+	}
+	rm := &resourceManager{sdkapi: mock, metrics: ackmetrics.NewMetrics("ecr")}
+
+	nonCompliant, err := rm.nonCompliantImageTags(context.Background(), testResource(), sp)
+	assert.NoError(err)
+	assert.Equal([]string{"unsigned"}, nonCompliant)
+}
+
+func Test_nonCompliantImageTags_fulcioOnlyNeverSatisfied(t *testing.T) {
+	assert := assert.New(t)
+
+	regex := ".*"
+	sp := &svcapitypes.SignaturePolicy{
+		Signers: []*svcapitypes.SignerIdentity{{FulcioIdentityRegex: &regex}},
+	}
+	mock := &mockECRAPI{
+		describeImagesPages: []*svcsdk.DescribeImagesOutput{{
+			ImageDetails: []*svcsdk.ImageDetail{imageDetail("signed")},
+		}},
+		signatureTags: map[string]bool{"sha256-abc.sig": true},
+	}
+	rm := &resourceManager{sdkapi: mock, metrics: ackmetrics.NewMetrics("ecr")}
+
+	nonCompliant, err := rm.nonCompliantImageTags(context.Background(), testResource(), sp)
+	assert.NoError(err)
+	assert.Equal([]string{"signed"}, nonCompliant)
+}
+
+func Test_setSignatureTagPresence(t *testing.T) {
+	assert := assert.New(t)
+
+	sp := &svcapitypes.SignaturePolicy{
+		Signers: []*svcapitypes.SignerIdentity{{PublicKeyRef: &ackv1alpha1.SecretKeyReference{}}},
+	}
+
+	t.Run("nil policy is a no-op", func(t *testing.T) {
+		rm := &resourceManager{sdkapi: &mockECRAPI{}, metrics: ackmetrics.NewMetrics("ecr")}
+		ko := &svcapitypes.Repository{Spec: svcapitypes.RepositorySpec{Name: testResource().ko.Spec.Name}}
+		assert.NoError(rm.setSignatureTagPresence(context.Background(), ko))
+		assert.Empty(ko.Status.Conditions)
+	})
+
+	t.Run("every tag signed sets condition True", func(t *testing.T) {
+		mock := &mockECRAPI{
+			describeImagesPages: []*svcsdk.DescribeImagesOutput{{
+				ImageDetails: []*svcsdk.ImageDetail{imageDetail("signed")},
+			}},
+			signatureTags: map[string]bool{"sha256-abc.sig": true},
+		}
+		rm := &resourceManager{sdkapi: mock, metrics: ackmetrics.NewMetrics("ecr")}
+		ko := testResource().ko.DeepCopy()
+		ko.Spec.SignaturePolicy = sp
+
+		assert.NoError(rm.setSignatureTagPresence(context.Background(), ko))
+		cond := findCondition(ko, ConditionTypeSignatureTagPresence)
+		assert.NotNil(cond)
+		assert.Equal(corev1.ConditionTrue, cond.Status)
+		assert.Nil(cond.Message)
+	})
+
+	t.Run("missing signature tag sets condition False with the tag named", func(t *testing.T) {
+		mock := &mockECRAPI{
+			describeImagesPages: []*svcsdk.DescribeImagesOutput{{
+				ImageDetails: []*svcsdk.ImageDetail{imageDetail("unsigned")},
+			}},
+			signatureTags: map[string]bool{},
+		}
+		rm := &resourceManager{sdkapi: mock, metrics: ackmetrics.NewMetrics("ecr")}
+		ko := testResource().ko.DeepCopy()
+		ko.Spec.SignaturePolicy = sp
+
+		assert.NoError(rm.setSignatureTagPresence(context.Background(), ko))
+		cond := findCondition(ko, ConditionTypeSignatureTagPresence)
+		assert.NotNil(cond)
+		assert.Equal(corev1.ConditionFalse, cond.Status)
+		assert.Contains(*cond.Message, "unsigned")
+	})
+}
+
+func findCondition(ko *svcapitypes.Repository, t ackv1alpha1.ConditionType) *ackv1alpha1.Condition {
+	for _, c := range ko.Status.Conditions {
+		if c.Type == t {
+			return c
+		}
+	}
+	return nil
+}
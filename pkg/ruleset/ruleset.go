@@ -0,0 +1,89 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package ruleset computes the add/remove delta between two sets of
+// registry-wide rule keys and records the outcome of applying that delta as
+// an ackv1alpha1.Condition. It is shared between RegistryScanningConfiguration
+// and RegistryReplicationConfiguration, both of which replace their entire
+// rule set with a single Put call and want to report what changed, and gate
+// that call on something having actually changed.
+package ruleset
+
+import (
+	"fmt"
+	"sort"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Delta returns the keys present in desired but not current (added), and
+// those present in current but not desired (removed). Callers reduce their
+// own rule type to a string key (e.g. a "ruleKey" function keyed on
+// whichever fields identify a rule) before calling this, since the rule
+// shapes otherwise have nothing in common beyond "a list that gets replaced
+// wholesale".
+func Delta(current, desired []string) (added, removed []string) {
+	currentKeys := map[string]bool{}
+	for _, k := range current {
+		currentKeys[k] = true
+	}
+	desiredKeys := map[string]bool{}
+	for _, k := range desired {
+		desiredKeys[k] = true
+	}
+	for k := range desiredKeys {
+		if !currentKeys[k] {
+			added = append(added, k)
+		}
+	}
+	for k := range currentKeys {
+		if !desiredKeys[k] {
+			removed = append(removed, k)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// SetSyncedCondition finds or creates a condType condition in *conditions
+// and records the outcome of a sync attempt: syncErr's message when it
+// failed, or a summary of the rules added/removed when it succeeded.
+func SetSyncedCondition(
+	conditions *[]*ackv1alpha1.Condition,
+	condType ackv1alpha1.ConditionType,
+	syncErr error,
+	added, removed []string,
+) {
+	var cond *ackv1alpha1.Condition
+	for _, c := range *conditions {
+		if c.Type == condType {
+			cond = c
+			break
+		}
+	}
+	if cond == nil {
+		cond = &ackv1alpha1.Condition{Type: condType}
+		*conditions = append(*conditions, cond)
+	}
+	if syncErr != nil {
+		cond.Status = corev1.ConditionFalse
+		msg := syncErr.Error()
+		cond.Message = &msg
+		return
+	}
+	cond.Status = corev1.ConditionTrue
+	msg := fmt.Sprintf("rule set synced (%d added, %d removed)", len(added), len(removed))
+	cond.Message = &msg
+}
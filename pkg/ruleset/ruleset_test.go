@@ -0,0 +1,54 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ruleset_test
+
+import (
+	"errors"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/ecr-controller/pkg/ruleset"
+)
+
+func Test_Delta(t *testing.T) {
+	assert := assert.New(t)
+
+	added, removed := ruleset.Delta([]string{"a", "b"}, []string{"b", "c"})
+	assert.Equal([]string{"c"}, added)
+	assert.Equal([]string{"a"}, removed)
+
+	added, removed = ruleset.Delta([]string{"a"}, []string{"a"})
+	assert.Empty(added)
+	assert.Empty(removed)
+}
+
+func Test_SetSyncedCondition(t *testing.T) {
+	assert := assert.New(t)
+
+	var conditions []*ackv1alpha1.Condition
+	condType := ackv1alpha1.ConditionType("ACK.Synced")
+
+	ruleset.SetSyncedCondition(&conditions, condType, nil, []string{"a"}, nil)
+	assert.Len(conditions, 1)
+	assert.Equal(corev1.ConditionTrue, conditions[0].Status)
+	assert.Equal("rule set synced (1 added, 0 removed)", *conditions[0].Message)
+
+	ruleset.SetSyncedCondition(&conditions, condType, errors.New("boom"), nil, nil)
+	assert.Len(conditions, 1)
+	assert.Equal(corev1.ConditionFalse, conditions[0].Status)
+	assert.Equal("boom", *conditions[0].Message)
+}